@@ -0,0 +1,191 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command wtrace prints the traces contained in trace log (trace.1) files or Zipkin JSON span files as indented
+// waterfalls. Traces can be searched by trace ID, span name or tag.
+//
+// Usage:
+//
+//	wtrace [flags] [file ...]
+//
+// If no files are provided (or a file is "-"), spans are read from standard input.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/tracetree"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if err != flag.ErrHelp {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(1)
+	}
+}
+
+// tagFilters is a flag.Value that accumulates tag filters of the form "key" or "key=value".
+type tagFilters []string
+
+func (f *tagFilters) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *tagFilters) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("wtrace", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	traceID := flags.String("trace-id", "", "only print the trace with this trace ID")
+	name := flags.String("name", "", "only print traces that contain a span whose name contains this string")
+	var tags tagFilters
+	flags.Var(&tags, "tag", "only print traces that contain a span with this tag, specified as \"key\" or \"key=value\" (may be repeated)")
+	printTags := flags.String("tags", "", "comma-separated list of the keys of the tags to print for each span, or \"*\" to print all tags")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: wtrace [flags] [file ...]\n\nPrints the traces in trace log or Zipkin JSON files as waterfalls. Reads from standard input if no files are provided.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	spans, err := readInputs(flags.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	match := spanMatcher(*name, tags)
+	var trees []*tracetree.Tree
+	for id, traceSpans := range tracetree.GroupByTrace(spans) {
+		if *traceID != "" && string(id) != *traceID {
+			continue
+		}
+		if !anySpanMatches(traceSpans, match) {
+			continue
+		}
+		tree, err := tracetree.Build(traceSpans)
+		if err != nil {
+			return err
+		}
+		trees = append(trees, tree)
+	}
+	sort.Slice(trees, func(i, j int) bool {
+		return trees[i].Roots[0].Start().Before(trees[j].Roots[0].Start())
+	})
+
+	p := &printer{
+		out:     stdout,
+		tagKeys: parseTagKeys(*printTags),
+		allTags: *printTags == "*",
+		match:   match,
+	}
+	for i, tree := range trees {
+		if i > 0 {
+			_, _ = fmt.Fprintln(stdout)
+		}
+		p.printTree(tree)
+	}
+	return nil
+}
+
+func readInputs(files []string, stdin io.Reader) ([]wtracing.SpanModel, error) {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	var spans []wtracing.SpanModel
+	for _, file := range files {
+		var fileSpans []wtracing.SpanModel
+		var err error
+		if file == "-" {
+			fileSpans, err = readSpans(stdin)
+		} else {
+			fileSpans, err = readFile(file)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spans from %s: %w", file, err)
+		}
+		spans = append(spans, fileSpans...)
+	}
+	return spans, nil
+}
+
+func readFile(file string) (spans []wtracing.SpanModel, rErr error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil && rErr == nil {
+			rErr = err
+		}
+	}()
+	return readSpans(f)
+}
+
+// spanMatcher returns a function that returns true if a span matches the provided search criteria, or nil if there are
+// no criteria.
+func spanMatcher(name string, tags tagFilters) func(span wtracing.SpanModel) bool {
+	if name == "" && len(tags) == 0 {
+		return nil
+	}
+	return func(span wtracing.SpanModel) bool {
+		if !strings.Contains(span.Name, name) {
+			return false
+		}
+		for _, tag := range tags {
+			key, value, hasValue := strings.Cut(tag, "=")
+			spanValue, ok := span.Tags[key]
+			if !ok || (hasValue && spanValue != value) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func anySpanMatches(spans []wtracing.SpanModel, match func(span wtracing.SpanModel) bool) bool {
+	if match == nil {
+		return true
+	}
+	for _, span := range spans {
+		if match(span) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTagKeys(tags string) []string {
+	if tags == "" || tags == "*" {
+		return nil
+	}
+	var keys []string
+	for _, key := range strings.Split(tags, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+	}{
+		{
+			name: "trace log and zipkin files",
+			args: []string{"-tags", "*", "testdata/trace.log", "testdata/zipkin.json"},
+			want: `Trace 463ac35c9f6413ad: 3 spans, 100ms
+          0s      100ms |========================================| GET /dashboard (server, frontend) http.status_code="200"
+        10ms       80ms |    ================================    |   GET /users (server, users) http.status_code="200"
+        20ms       30ms |        ============                    |     SELECT users db.statement="SELECT * FROM users"
+
+Trace 0000000000000002: 1 span, 5ms
+          0s        5ms |========================================| background job
+
+Trace 5759e988bd862e3fe1be46a994272793: 2 spans, 40ms
+          0s       40ms |========================================| get /api (server, api) http.path="/api"
+        10ms       20ms |          ====================          |   get /backend (client, api) error="503" http.path="/backend"
+`,
+		},
+		{
+			name: "search by name and tag marks matching spans",
+			args: []string{"-name", "users", "-tag", "http.status_code=200", "-tags", "http.status_code", "testdata/trace.log"},
+			want: `Trace 463ac35c9f6413ad: 3 spans, 100ms
+          0s      100ms |========================================| GET /dashboard (server, frontend) http.status_code="200"
+*       10ms       80ms |    ================================    |   GET /users (server, users) http.status_code="200"
+        20ms       30ms |        ============                    |     SELECT users
+`,
+		},
+		{
+			name:  "search by trace ID from stdin",
+			args:  []string{"-trace-id", "0000000000000002"},
+			stdin: readTestFile(t, "testdata/trace.log"),
+			want: `Trace 0000000000000002: 1 span, 5ms
+          0s        5ms |========================================| background job
+`,
+		},
+		{
+			name: "no matches",
+			args: []string{"-tag", "missing", "testdata/trace.log"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			err := run(tc.args, strings.NewReader(tc.stdin), stdout, &bytes.Buffer{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, stdout.String())
+		})
+	}
+}
+
+func TestRunError(t *testing.T) {
+	err := run([]string{"testdata/missing.json"}, strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Contains(t, err.Error(), "failed to read spans from testdata/missing.json")
+
+	err = run(nil, strings.NewReader("[{\"traceId\": 1}]"), &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Contains(t, err.Error(), "failed to decode JSON array of spans")
+
+	err = run(nil, strings.NewReader("not json"), &bytes.Buffer{}, &bytes.Buffer{})
+	assert.Contains(t, err.Error(), "failed to decode line 1")
+}
+
+func readTestFile(t *testing.T, file string) string {
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	return string(b)
+}
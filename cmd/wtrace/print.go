@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/tracetree"
+)
+
+const barWidth = 40
+
+// printer prints trace trees as waterfalls.
+type printer struct {
+	out io.Writer
+	// tagKeys are the keys of the tags that are printed for each span.
+	tagKeys []string
+	// allTags is true if all tags are printed for each span.
+	allTags bool
+	// match returns true for spans that match the search criteria, which are marked in the output. Nil if there are
+	// no search criteria.
+	match func(span wtracing.SpanModel) bool
+}
+
+// printTree prints the provided tree. Every span is printed on its own line with its offset from the start of the
+// trace, its duration, a bar showing when the span was in progress relative to the whole trace, and its name indented
+// by its depth in the tree.
+func (p *printer) printTree(tree *tracetree.Tree) {
+	var start, end time.Time
+	count := 0
+	tree.Walk(func(node *tracetree.Node) {
+		if start.IsZero() || node.Start().Before(start) {
+			start = node.Start()
+		}
+		if node.End().After(end) {
+			end = node.End()
+		}
+		count++
+	})
+	total := end.Sub(start)
+
+	spansLabel := "spans"
+	if count == 1 {
+		spansLabel = "span"
+	}
+	_, _ = fmt.Fprintf(p.out, "Trace %s: %d %s, %s\n", tree.TraceID, count, spansLabel, total)
+	tree.Walk(func(node *tracetree.Node) {
+		marker := " "
+		if p.match != nil && p.match(node.Span) {
+			marker = "*"
+		}
+		_, _ = fmt.Fprintf(p.out, "%s %10s %10s |%s| %s%s%s\n",
+			marker,
+			node.Start().Sub(start),
+			node.Span.Duration,
+			bar(node.Start().Sub(start), node.Span.Duration, total),
+			strings.Repeat("  ", node.Depth()),
+			node.Span.Name,
+			p.details(node),
+		)
+	})
+}
+
+func (p *printer) details(node *tracetree.Node) string {
+	var details []string
+	if kind := node.Span.Kind; kind != wtracing.Undetermined {
+		details = append(details, strings.ToLower(string(kind)))
+	}
+	if endpoint := node.Span.LocalEndpoint; endpoint != nil && endpoint.ServiceName != "" {
+		details = append(details, endpoint.ServiceName)
+	}
+	if node.Orphan() {
+		details = append(details, "orphan")
+	}
+	var out string
+	if len(details) > 0 {
+		out = " (" + strings.Join(details, ", ") + ")"
+	}
+
+	keys := p.tagKeys
+	if p.allTags {
+		keys = make([]string, 0, len(node.Span.Tags))
+		for k := range node.Span.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	}
+	for _, k := range keys {
+		if v, ok := node.Span.Tags[k]; ok {
+			out += fmt.Sprintf(" %s=%q", k, v)
+		}
+	}
+	return out
+}
+
+// bar returns a bar of width barWidth in which the portion that corresponds to the provided interval within the total
+// duration is filled.
+func bar(offset, duration, total time.Duration) string {
+	if total <= 0 {
+		return strings.Repeat("=", barWidth)
+	}
+	startCol := int(int64(offset) * barWidth / int64(total))
+	endCol := int((int64(offset+duration)*barWidth + int64(total) - 1) / int64(total))
+	if endCol > barWidth {
+		endCol = barWidth
+	}
+	if startCol >= barWidth {
+		startCol = barWidth - 1
+	}
+	if endCol <= startCol {
+		// always show at least one column so that short spans are visible
+		endCol = startCol + 1
+	}
+	return strings.Repeat(" ", startCol) + strings.Repeat("=", endCol-startCol) + strings.Repeat(" ", barWidth-endCol)
+}
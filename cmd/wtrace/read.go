@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// jsonSpan is the JSON representation of a span. It covers both the Zipkin v2 JSON format and the format of the "span"
+// field of trace.1 log entries, which uses Zipkin v1 style annotations that carry the endpoint of the span.
+type jsonSpan struct {
+	TraceID        string            `json:"traceId"`
+	ID             string            `json:"id"`
+	ParentID       string            `json:"parentId"`
+	Name           string            `json:"name"`
+	Kind           string            `json:"kind"`
+	Timestamp      int64             `json:"timestamp"`
+	Duration       int64             `json:"duration"`
+	Debug          bool              `json:"debug"`
+	LocalEndpoint  *jsonEndpoint     `json:"localEndpoint"`
+	RemoteEndpoint *jsonEndpoint     `json:"remoteEndpoint"`
+	Annotations    []jsonAnnotation  `json:"annotations"`
+	Tags           map[string]string `json:"tags"`
+}
+
+type jsonEndpoint struct {
+	ServiceName string `json:"serviceName"`
+	IPv4        string `json:"ipv4"`
+	IPv6        string `json:"ipv6"`
+	Port        uint16 `json:"port"`
+}
+
+type jsonAnnotation struct {
+	Timestamp int64         `json:"timestamp"`
+	Value     string        `json:"value"`
+	Endpoint  *jsonEndpoint `json:"endpoint"`
+}
+
+// traceLogEntry is a trace.1 log entry.
+type traceLogEntry struct {
+	Type string    `json:"type"`
+	Span *jsonSpan `json:"span"`
+}
+
+// readSpans reads the spans from the provided reader. The input may be a Zipkin v2 JSON array of spans or
+// newline-delimited JSON where every line is a trace.1 log entry or a Zipkin v2 JSON span. Lines of newline-delimited
+// input that are not spans (such as entries of other log types) are skipped.
+func readSpans(r io.Reader) ([]wtracing.SpanModel, error) {
+	br := bufio.NewReader(r)
+	first, err := peekNonSpace(br)
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if first == '[' {
+		var spans []jsonSpan
+		if err := json.NewDecoder(br).Decode(&spans); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON array of spans: %w", err)
+		}
+		models := make([]wtracing.SpanModel, len(spans))
+		for i, span := range spans {
+			models[i] = span.toSpanModel()
+		}
+		return models, nil
+	}
+
+	var models []wtracing.SpanModel
+	scanner := bufio.NewScanner(br)
+	// trace log lines may be long if spans have many tags
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry traceLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode line %d: %w", lineNum, err)
+		}
+		if entry.Type != "" {
+			// log entry: only trace log entries contain spans
+			if entry.Type == "trace.1" && entry.Span != nil {
+				models = append(models, entry.Span.toSpanModel())
+			}
+			continue
+		}
+		var span jsonSpan
+		if err := json.Unmarshal(line, &span); err != nil {
+			return nil, fmt.Errorf("failed to decode line %d: %w", lineNum, err)
+		}
+		if span.TraceID != "" {
+			models = append(models, span.toSpanModel())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	return models, nil
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, br.UnreadByte()
+	}
+}
+
+func (s jsonSpan) toSpanModel() wtracing.SpanModel {
+	model := wtracing.SpanModel{
+		SpanContext: wtracing.SpanContext{
+			TraceID: wtracing.TraceID(s.TraceID),
+			ID:      wtracing.SpanID(s.ID),
+			Debug:   s.Debug,
+		},
+		Name:           s.Name,
+		Kind:           wtracing.Kind(s.Kind),
+		Timestamp:      fromEpochMicros(s.Timestamp),
+		Duration:       time.Duration(s.Duration) * time.Microsecond,
+		LocalEndpoint:  s.LocalEndpoint.toEndpoint(),
+		RemoteEndpoint: s.RemoteEndpoint.toEndpoint(),
+		Tags:           s.Tags,
+	}
+	if s.ParentID != "" {
+		parentID := wtracing.SpanID(s.ParentID)
+		model.ParentID = &parentID
+	}
+	for _, annotation := range s.Annotations {
+		model.Annotations = append(model.Annotations, wtracing.Annotation{
+			Timestamp: fromEpochMicros(annotation.Timestamp),
+			Value:     annotation.Value,
+		})
+		// Zipkin v1 style annotations identify the kind and local endpoint of the span
+		switch annotation.Value {
+		case "cs", "cr", "sr", "ss":
+			if model.Kind == wtracing.Undetermined {
+				model.Kind = wtracing.Client
+				if annotation.Value == "sr" || annotation.Value == "ss" {
+					model.Kind = wtracing.Server
+				}
+			}
+			if model.LocalEndpoint == nil {
+				model.LocalEndpoint = annotation.Endpoint.toEndpoint()
+			}
+		}
+	}
+	return model
+}
+
+func (e *jsonEndpoint) toEndpoint() *wtracing.Endpoint {
+	if e == nil {
+		return nil
+	}
+	return &wtracing.Endpoint{
+		ServiceName: e.ServiceName,
+		IPv4:        net.ParseIP(e.IPv4),
+		IPv6:        net.ParseIP(e.IPv6),
+		Port:        e.Port,
+	}
+}
+
+func fromEpochMicros(micros int64) time.Time {
+	return time.Unix(0, micros*int64(time.Microsecond)).UTC()
+}
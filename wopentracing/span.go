@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wopentracing
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// OperationNameTagKey is the key of the tag used to record the operation name set using SetOperationName.
+const OperationNameTagKey = "operation.name"
+
+type spanContext struct {
+	sc      wtracing.SpanContext
+	baggage map[string]string
+}
+
+func (c spanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.baggage {
+		if !handler(k, v) {
+			return
+		}
+	}
+}
+
+type spanImpl struct {
+	tracer *tracerImpl
+	span   wtracing.Span
+
+	mutex   sync.RWMutex
+	baggage map[string]string
+}
+
+// Span returns the wtracing.Span that backs the provided OpenTracing span. Returns nil if the provided span was not
+// created by a tracer returned by NewTracer.
+func Span(span opentracing.Span) wtracing.Span {
+	if s, ok := span.(*spanImpl); ok {
+		return s.span
+	}
+	return nil
+}
+
+func (s *spanImpl) Finish() {
+	s.span.Finish()
+}
+
+func (s *spanImpl) FinishWithOptions(opts opentracing.FinishOptions) {
+	for _, lr := range opts.LogRecords {
+		s.annotate(lr.Timestamp, lr.Fields)
+	}
+	for _, ld := range opts.BulkLogData {
+		lr := ld.ToLogRecord()
+		s.annotate(lr.Timestamp, lr.Fields)
+	}
+	if finisher, ok := s.span.(wtracing.SpanFinisher); ok && !opts.FinishTime.IsZero() {
+		finisher.FinishAt(opts.FinishTime)
+		return
+	}
+	s.span.Finish()
+}
+
+func (s *spanImpl) Context() opentracing.SpanContext {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return spanContext{
+		sc:      s.span.Context(),
+		baggage: copyBaggage(s.baggage),
+	}
+}
+
+// SetOperationName records the provided name as the OperationNameTagKey tag of the span: the name of a wtracing span
+// cannot be changed after it is started.
+func (s *spanImpl) SetOperationName(operationName string) opentracing.Span {
+	s.span.Tag(OperationNameTagKey, operationName)
+	return s
+}
+
+func (s *spanImpl) SetTag(key string, value interface{}) opentracing.Span {
+	s.span.Tag(key, tagValue(value))
+	return s
+}
+
+func (s *spanImpl) LogFields(fields ...log.Field) {
+	s.annotate(time.Time{}, fields)
+}
+
+func (s *spanImpl) LogKV(alternatingKeyValues ...interface{}) {
+	fields, err := log.InterleavedKVToFields(alternatingKeyValues...)
+	if err != nil {
+		s.LogFields(log.Error(err), log.String("function", "LogKV"))
+		return
+	}
+	s.LogFields(fields...)
+}
+
+func (s *spanImpl) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.baggage == nil {
+		s.baggage = make(map[string]string)
+	}
+	s.baggage[restrictedKey] = value
+	return s
+}
+
+func (s *spanImpl) BaggageItem(restrictedKey string) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.baggage[restrictedKey]
+}
+
+func (s *spanImpl) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+func (s *spanImpl) LogEvent(event string) {
+	s.LogFields(log.String("event", event))
+}
+
+func (s *spanImpl) LogEventWithPayload(event string, payload interface{}) {
+	s.LogFields(log.String("event", event), log.Object("payload", payload))
+}
+
+func (s *spanImpl) Log(ld opentracing.LogData) {
+	lr := ld.ToLogRecord()
+	s.annotate(lr.Timestamp, lr.Fields)
+}
+
+// annotate records the provided fields as an annotation on the underlying span if it supports annotations. A single
+// "event" field is recorded as its value; otherwise, the fields are recorded as space-separated "key:value" pairs.
+func (s *spanImpl) annotate(timestamp time.Time, fields []log.Field) {
+	annotator, ok := s.span.(wtracing.SpanAnnotator)
+	if !ok || len(fields) == 0 {
+		return
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	if len(fields) == 1 && fields[0].Key() == "event" {
+		annotator.Annotate(timestamp, fmt.Sprint(fields[0].Value()))
+		return
+	}
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.String()
+	}
+	annotator.Annotate(timestamp, strings.Join(parts, " "))
+}
+
+func copyBaggage(baggage map[string]string) map[string]string {
+	if len(baggage) == 0 {
+		return nil
+	}
+	baggageCopy := make(map[string]string, len(baggage))
+	for k, v := range baggage {
+		baggageCopy[k] = v
+	}
+	return baggageCopy
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wopentracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/propagation/b3"
+)
+
+// NewTracer returns an opentracing.Tracer that creates spans using the provided wtracing.Tracer. Span contexts are
+// injected into and extracted from carriers using the B3 header format: the opentracing.HTTPHeaders format is
+// supported with carriers of type opentracing.HTTPHeadersCarrier or http.Header.
+func NewTracer(tracer wtracing.Tracer) opentracing.Tracer {
+	return &tracerImpl{
+		tracer: tracer,
+	}
+}
+
+type tracerImpl struct {
+	tracer wtracing.Tracer
+}
+
+func (t *tracerImpl) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	var startSpanOpts opentracing.StartSpanOptions
+	for _, opt := range opts {
+		opt.Apply(&startSpanOpts)
+	}
+
+	var spanOpts []wtracing.SpanOption
+	var baggage map[string]string
+	if parent, ok := parentContext(startSpanOpts.References); ok {
+		spanOpts = append(spanOpts, wtracing.WithParentSpanContext(parent.sc))
+		baggage = parent.baggage
+	}
+	if !startSpanOpts.StartTime.IsZero() {
+		spanOpts = append(spanOpts, wtracing.WithStartTime(startSpanOpts.StartTime))
+	}
+	for k, v := range startSpanOpts.Tags {
+		if k == string(ext.SpanKind) {
+			spanOpts = append(spanOpts, wtracing.WithKind(fromSpanKind(v)))
+			continue
+		}
+		spanOpts = append(spanOpts, wtracing.WithSpanTag(k, tagValue(v)))
+	}
+
+	return &spanImpl{
+		tracer:  t,
+		span:    t.tracer.StartSpan(operationName, spanOpts...),
+		baggage: copyBaggage(baggage),
+	}
+}
+
+func (t *tracerImpl) Inject(sm opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	sc, ok := sm.(spanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+	if format != opentracing.HTTPHeaders {
+		return opentracing.ErrUnsupportedFormat
+	}
+	header, ok := httpHeader(carrier)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+	b3.HeaderSpanInjector(header)(sc.sc)
+	return nil
+}
+
+func (t *tracerImpl) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	if format != opentracing.HTTPHeaders {
+		return nil, opentracing.ErrUnsupportedFormat
+	}
+	header, ok := httpHeader(carrier)
+	if !ok {
+		return nil, opentracing.ErrInvalidCarrier
+	}
+	sc := b3.HeaderSpanExtractor(header)()
+	if sc.TraceID == "" && sc.ID == "" {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+	if sc.Err != nil {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	return spanContext{sc: sc}, nil
+}
+
+// parentContext returns the context that should be used as the parent of a new span. wtracing spans have a single
+// parent, so the first ChildOf reference is used if one exists and the first FollowsFrom reference is used otherwise.
+func parentContext(refs []opentracing.SpanReference) (spanContext, bool) {
+	var parent spanContext
+	var found bool
+	for _, ref := range refs {
+		refCtx, ok := ref.ReferencedContext.(spanContext)
+		if !ok {
+			continue
+		}
+		if ref.Type == opentracing.ChildOfRef {
+			return refCtx, true
+		}
+		if !found {
+			parent, found = refCtx, true
+		}
+	}
+	return parent, found
+}
+
+func httpHeader(carrier interface{}) (http.Header, bool) {
+	switch c := carrier.(type) {
+	case opentracing.HTTPHeadersCarrier:
+		return http.Header(c), true
+	case http.Header:
+		return c, true
+	default:
+		return nil, false
+	}
+}
+
+func fromSpanKind(kind interface{}) wtracing.Kind {
+	switch fmt.Sprint(kind) {
+	case string(ext.SpanKindRPCClientEnum):
+		return wtracing.Client
+	case string(ext.SpanKindRPCServerEnum):
+		return wtracing.Server
+	case string(ext.SpanKindProducerEnum):
+		return wtracing.Producer
+	case string(ext.SpanKindConsumerEnum):
+		return wtracing.Consumer
+	default:
+		return wtracing.Undetermined
+	}
+}
+
+func tagValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wopentracing_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/palantir/witchcraft-go-tracing/wopentracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTracer(t *testing.T) (opentracing.Tracer, *wtracingtest.RecordingReporter) {
+	reporter := wtracingtest.NewRecordingReporter()
+	wtracingTracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+	return wopentracing.NewTracer(wtracingTracer), reporter
+}
+
+func TestStartSpan(t *testing.T) {
+	tracer, reporter := newTracer(t)
+
+	parent := tracer.StartSpan("parent", ext.SpanKindRPCServer)
+	parent.SetBaggageItem("user", "alice")
+	child := tracer.StartSpan("child",
+		opentracing.ChildOf(parent.Context()),
+		opentracing.Tag{Key: "attempt", Value: 2},
+		opentracing.Tag{Key: "string", Value: "value"},
+	)
+	child.SetTag("retried", true)
+	assert.Equal(t, "alice", child.BaggageItem("user"))
+	child.Finish()
+	parent.Finish()
+
+	spans := reporter.Spans()
+	parentModel := wtracingtest.RequireSpan(t, spans, "parent")
+	childModel := wtracingtest.RequireSpan(t, spans, "child")
+	wtracingtest.AssertParentChild(t, parentModel, childModel)
+	assert.Equal(t, wtracing.Server, parentModel.Kind)
+	assert.Equal(t, map[string]string{}, parentModel.Tags)
+	assert.Equal(t, map[string]string{
+		"attempt": "2",
+		"string":  "value",
+		"retried": "true",
+	}, childModel.Tags)
+}
+
+func TestStartSpanFollowsFrom(t *testing.T) {
+	tracer, reporter := newTracer(t)
+
+	parent := tracer.StartSpan("parent")
+	other := tracer.StartSpan("other")
+	child := tracer.StartSpan("child", opentracing.FollowsFrom(other.Context()), opentracing.ChildOf(parent.Context()))
+	child.Finish()
+	follower := tracer.StartSpan("follower", opentracing.FollowsFrom(other.Context()))
+	follower.Finish()
+	other.Finish()
+	parent.Finish()
+
+	spans := reporter.Spans()
+	wtracingtest.AssertParentChild(t, wtracingtest.RequireSpan(t, spans, "parent"), wtracingtest.RequireSpan(t, spans, "child"))
+	wtracingtest.AssertParentChild(t, wtracingtest.RequireSpan(t, spans, "other"), wtracingtest.RequireSpan(t, spans, "follower"))
+}
+
+func TestLogFields(t *testing.T) {
+	tracer, reporter := newTracer(t)
+
+	logTime := time.Now()
+	span := tracer.StartSpan("span")
+	span.LogFields(log.String("event", "cache miss"))
+	span.LogKV("key", "value", "count", 3)
+	span.FinishWithOptions(opentracing.FinishOptions{
+		LogRecords: []opentracing.LogRecord{{
+			Timestamp: logTime,
+			Fields:    []log.Field{log.Bool("flushed", true)},
+		}},
+	})
+
+	annotations := wtracingtest.RequireSpan(t, reporter.Spans(), "span").Annotations
+	require.Len(t, annotations, 3)
+	assert.Equal(t, "cache miss", annotations[0].Value)
+	assert.Equal(t, "key:value count:3", annotations[1].Value)
+	assert.Equal(t, wtracing.Annotation{Timestamp: logTime, Value: "flushed:true"}, annotations[2])
+}
+
+func TestStartAndFinishTime(t *testing.T) {
+	tracer, reporter := newTracer(t)
+
+	startTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	span := tracer.StartSpan("span", opentracing.StartTime(startTime))
+	span.FinishWithOptions(opentracing.FinishOptions{
+		FinishTime: startTime.Add(3 * time.Second),
+	})
+
+	spanModel := wtracingtest.RequireSpan(t, reporter.Spans(), "span")
+	assert.Equal(t, startTime, spanModel.Timestamp)
+	assert.Equal(t, 3*time.Second, spanModel.Duration)
+}
+
+func TestSetOperationName(t *testing.T) {
+	tracer, reporter := newTracer(t)
+
+	span := tracer.StartSpan("span")
+	span.SetOperationName("renamed")
+	span.Finish()
+
+	assert.Equal(t, map[string]string{
+		wopentracing.OperationNameTagKey: "renamed",
+	}, wtracingtest.RequireSpan(t, reporter.Spans(), "span").Tags)
+}
+
+func TestInjectExtract(t *testing.T) {
+	tracer, reporter := newTracer(t)
+
+	span := tracer.StartSpan("client")
+	header := http.Header{}
+	require.NoError(t, tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header)))
+	assert.Equal(t, string(wopentracing.Span(span).Context().TraceID), header.Get("X-B3-TraceId"))
+	assert.Equal(t, string(wopentracing.Span(span).Context().ID), header.Get("X-B3-SpanId"))
+
+	extracted, err := tracer.Extract(opentracing.HTTPHeaders, header)
+	require.NoError(t, err)
+	server := tracer.StartSpan("server", ext.RPCServerOption(extracted))
+	server.Finish()
+	span.Finish()
+
+	spans := reporter.Spans()
+	serverModel := wtracingtest.RequireSpan(t, spans, "server")
+	wtracingtest.AssertParentChild(t, wtracingtest.RequireSpan(t, spans, "client"), serverModel)
+	assert.Equal(t, wtracing.Server, serverModel.Kind)
+}
+
+func TestInjectExtractErrors(t *testing.T) {
+	tracer, _ := newTracer(t)
+	span := tracer.StartSpan("span")
+
+	assert.Equal(t, opentracing.ErrUnsupportedFormat, tracer.Inject(span.Context(), opentracing.Binary, nil))
+	assert.Equal(t, opentracing.ErrInvalidCarrier, tracer.Inject(span.Context(), opentracing.HTTPHeaders, map[string]string{}))
+	assert.Equal(t, opentracing.ErrInvalidSpanContext, tracer.Inject(opentracing.NoopTracer{}.StartSpan("noop").Context(), opentracing.HTTPHeaders, http.Header{}))
+
+	_, err := tracer.Extract(opentracing.TextMap, http.Header{})
+	assert.Equal(t, opentracing.ErrUnsupportedFormat, err)
+	_, err = tracer.Extract(opentracing.HTTPHeaders, "carrier")
+	assert.Equal(t, opentracing.ErrInvalidCarrier, err)
+	_, err = tracer.Extract(opentracing.HTTPHeaders, http.Header{})
+	assert.Equal(t, opentracing.ErrSpanContextNotFound, err)
+	_, err = tracer.Extract(opentracing.HTTPHeaders, http.Header{"X-B3-Traceid": []string{"6c2f558d62a7085f"}})
+	assert.Equal(t, opentracing.ErrSpanContextCorrupted, err)
+}
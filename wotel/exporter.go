@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotel
+
+import (
+	"context"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewSpanExporter returns an OpenTelemetry SpanExporter that converts the spans it receives into wtracing.SpanModel
+// values using FromReadOnlySpan and sends them to the provided reporter. Shutting down the exporter closes the
+// reporter.
+func NewSpanExporter(reporter wtracing.Reporter) sdktrace.SpanExporter {
+	return &spanExporter{
+		reporter: reporter,
+	}
+}
+
+type spanExporter struct {
+	reporter wtracing.Reporter
+}
+
+func (e *spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	for _, span := range spans {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e.reporter.Send(FromReadOnlySpan(span))
+	}
+	return nil
+}
+
+func (e *spanExporter) Shutdown(ctx context.Context) error {
+	return e.reporter.Close()
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotel_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wotel"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanExporter(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(wotel.NewSpanExporter(reporter)),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "my-service"))),
+	)
+	tracer := provider.Tracer("my-library", trace.WithInstrumentationVersion("1.0.0"))
+
+	ctx, parent := tracer.Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "child",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("peer.service", "remote-service"),
+			attribute.String("network.peer.address", "10.0.0.1"),
+			attribute.Int("network.peer.port", 8443),
+			attribute.Bool("retried", true),
+		),
+	)
+	child.SetStatus(codes.Error, "request failed")
+	child.End()
+	parent.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	spans := reporter.Spans()
+	parentModel := wtracingtest.RequireSpan(t, spans, "parent")
+	childModel := wtracingtest.RequireSpan(t, spans, "child")
+	wtracingtest.AssertParentChild(t, parentModel, childModel)
+
+	assert.Equal(t, wotel.FromTraceID(parent.SpanContext().TraceID()), parentModel.TraceID)
+	assert.Equal(t, wotel.FromSpanID(parent.SpanContext().SpanID()), parentModel.ID)
+	assert.Nil(t, parentModel.ParentID)
+	assert.Equal(t, wtracing.Server, parentModel.Kind)
+	assert.Equal(t, &wtracing.Endpoint{ServiceName: "my-service"}, parentModel.LocalEndpoint)
+	assert.Nil(t, parentModel.RemoteEndpoint)
+	require.NotNil(t, parentModel.Sampled)
+	assert.True(t, *parentModel.Sampled)
+	assert.Equal(t, parent.(sdktrace.ReadOnlySpan).StartTime(), parentModel.Timestamp)
+	assert.Equal(t, parent.(sdktrace.ReadOnlySpan).EndTime().Sub(parentModel.Timestamp), parentModel.Duration)
+
+	assert.Equal(t, wtracing.Client, childModel.Kind)
+	assert.Equal(t, &wtracing.Endpoint{
+		ServiceName: "remote-service",
+		IPv4:        net.ParseIP("10.0.0.1").To4(),
+		Port:        8443,
+	}, childModel.RemoteEndpoint)
+	assert.Equal(t, map[string]string{
+		"peer.service":         "remote-service",
+		"network.peer.address": "10.0.0.1",
+		"network.peer.port":    "8443",
+		"retried":              "true",
+		"otel.scope.name":      "my-library",
+		"otel.scope.version":   "1.0.0",
+		"otel.status_code":     "Error",
+		"error":                "request failed",
+	}, childModel.Tags)
+}
+
+func TestSpanExporterErrorWithoutDescription(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(wotel.NewSpanExporter(reporter)))
+
+	_, span := provider.Tracer("my-library").Start(context.Background(), "span")
+	span.SetStatus(codes.Error, "")
+	span.End()
+
+	assert.Equal(t, "unknown error", wtracingtest.RequireSpan(t, reporter.Spans(), "span").Tags["error"])
+}
+
+func TestSpanExporterSharesTraceWithWTracing(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	wtracingTracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(wotel.NewSpanExporter(reporter)))
+
+	ctx := wtracing.ContextWithTracer(context.Background(), wtracingTracer)
+	wtracingSpan, ctx := wtracing.StartSpanFromTracerInContext(ctx, "wtracing-span")
+
+	_, otelSpan := provider.Tracer("my-library").Start(wotel.ContextWithSpanContextFromWTracing(ctx), "otel-span")
+	otelSpan.End()
+	wtracingSpan.Finish()
+
+	spans := reporter.Spans()
+	wtracingtest.AssertParentChild(t, wtracingtest.RequireSpan(t, spans, "wtracing-span"), wtracingtest.RequireSpan(t, spans, "otel-span"))
+}
+
+func TestSpanExporterShutdown(t *testing.T) {
+	reporter := &closeRecordingReporter{}
+	exporter := wotel.NewSpanExporter(reporter)
+	require.NoError(t, exporter.Shutdown(context.Background()))
+	assert.True(t, reporter.closed)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := exporter.ExportSpans(ctx, []sdktrace.ReadOnlySpan{nil})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+type closeRecordingReporter struct {
+	closed bool
+}
+
+func (r *closeRecordingReporter) Send(wtracing.SpanModel) {}
+
+func (r *closeRecordingReporter) Close() error {
+	r.closed = true
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotel
+
+import (
+	"context"
+	"strings"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FromTraceID returns the wtracing.TraceID for the provided OpenTelemetry TraceID. Consistent with the IDs generated
+// by wzipkin, the returned ID is 16 hex characters long if the upper 64 bits of the ID are 0 and 32 hex characters
+// long otherwise.
+func FromTraceID(traceID trace.TraceID) wtracing.TraceID {
+	hexID := traceID.String()
+	if strings.HasPrefix(hexID, "0000000000000000") {
+		hexID = hexID[16:]
+	}
+	return wtracing.TraceID(hexID)
+}
+
+// FromSpanID returns the wtracing.SpanID for the provided OpenTelemetry SpanID.
+func FromSpanID(spanID trace.SpanID) wtracing.SpanID {
+	return wtracing.SpanID(spanID.String())
+}
+
+// ToTraceID returns the OpenTelemetry TraceID for the provided wtracing.TraceID, which must be a 16 or 32 character
+// hex string. 64-bit IDs are stored in the lower 64 bits of the returned ID.
+func ToTraceID(traceID wtracing.TraceID) (trace.TraceID, error) {
+	b, err := wtracing.TraceIDBytes(traceID)
+	if err != nil {
+		return trace.TraceID{}, err
+	}
+	return trace.TraceID(b), nil
+}
+
+// ToSpanID returns the OpenTelemetry SpanID for the provided wtracing.SpanID, which must be a 16 character hex string.
+func ToSpanID(spanID wtracing.SpanID) (trace.SpanID, error) {
+	b, err := wtracing.SpanIDBytes(spanID)
+	if err != nil {
+		return trace.SpanID{}, err
+	}
+	return trace.SpanID(b), nil
+}
+
+// ToSpanContext returns a remote OpenTelemetry SpanContext that has the same TraceID, SpanID and sampling decision as
+// the provided wtracing.SpanContext. Spans in debug mode are marked as sampled. Returns an error if the TraceID or
+// SpanID of the provided context is not valid.
+func ToSpanContext(sc wtracing.SpanContext) (trace.SpanContext, error) {
+	traceID, err := ToTraceID(sc.TraceID)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	spanID, err := ToSpanID(sc.ID)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	var flags trace.TraceFlags
+	if sc.Debug || (sc.Sampled != nil && *sc.Sampled) {
+		flags = trace.FlagsSampled
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		Remote:     true,
+	}), nil
+}
+
+// ContextWithSpanContextFromWTracing returns a copy of the provided context in which the current wtracing span (as
+// returned by wtracing.SpanContextFromContext) is set as the remote OpenTelemetry parent span. Spans started by
+// OpenTelemetry instrumentation using the returned context will share the TraceID of the wtracing span and will be its
+// children. Returns the provided context unmodified if it does not contain a valid wtracing span.
+func ContextWithSpanContextFromWTracing(ctx context.Context) context.Context {
+	sc, ok := wtracing.SpanContextFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	otelSpanCtx, err := ToSpanContext(sc)
+	if err != nil {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, otelSpanCtx)
+}
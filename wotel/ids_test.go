@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotel_test
+
+import (
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wotel"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceIDRoundTrip(t *testing.T) {
+	for _, id := range []wtracing.TraceID{
+		"6c2f558d62a7085f",
+		"7a3e447c51b1244b6c2f558d62a7085f",
+	} {
+		otelID, err := wotel.ToTraceID(id)
+		require.NoError(t, err)
+		assert.Equal(t, id, wotel.FromTraceID(otelID))
+	}
+
+	_, err := wotel.ToTraceID("abc")
+	assert.Error(t, err)
+	_, err = wotel.ToTraceID("zzzzzzzzzzzzzzzz")
+	assert.Error(t, err)
+}
+
+func TestSpanIDRoundTrip(t *testing.T) {
+	otelID, err := wotel.ToSpanID("6c2f558d62a7085f")
+	require.NoError(t, err)
+	assert.Equal(t, wtracing.SpanID("6c2f558d62a7085f"), wotel.FromSpanID(otelID))
+
+	_, err = wotel.ToSpanID("6c2f558d62a7085f00")
+	assert.Error(t, err)
+}
+
+func TestToSpanContext(t *testing.T) {
+	sampled := true
+	sc, err := wotel.ToSpanContext(wtracing.SpanContext{
+		TraceID: "6c2f558d62a7085f",
+		ID:      "7a3e447c51b1244b",
+		Sampled: &sampled,
+	})
+	require.NoError(t, err)
+	assert.True(t, sc.IsValid())
+	assert.True(t, sc.IsRemote())
+	assert.True(t, sc.IsSampled())
+	assert.Equal(t, "00000000000000006c2f558d62a7085f", sc.TraceID().String())
+	assert.Equal(t, "7a3e447c51b1244b", sc.SpanID().String())
+
+	sc, err = wotel.ToSpanContext(wtracing.SpanContext{
+		TraceID: "6c2f558d62a7085f",
+		ID:      "7a3e447c51b1244b",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, trace.TraceFlags(0), sc.TraceFlags())
+
+	_, err = wotel.ToSpanContext(wtracing.SpanContext{TraceID: "6c2f558d62a7085f"})
+	assert.Error(t, err)
+}
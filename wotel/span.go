@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotel
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	serviceNameKey = attribute.Key("service.name")
+
+	// keys used to populate the remote endpoint, in order of precedence
+	peerServiceKey  = attribute.Key("peer.service")
+	serverAddrKey   = attribute.Key("server.address")
+	serverPortKey   = attribute.Key("server.port")
+	netPeerNameKey  = attribute.Key("net.peer.name")
+	netPeerIPKey    = attribute.Key("net.peer.ip")
+	netPeerPortKey  = attribute.Key("net.peer.port")
+	peerAddressKey  = attribute.Key("network.peer.address")
+	peerPortKey     = attribute.Key("network.peer.port")
+	scopeNameTag    = "otel.scope.name"
+	scopeVersionTag = "otel.scope.version"
+	statusCodeTag   = "otel.status_code"
+
+	// defaultErrorTagValue is the value of the error tag for spans with an error status that has no description
+	defaultErrorTagValue = "unknown error"
+)
+
+// FromReadOnlySpan converts the provided OpenTelemetry span into a wtracing.SpanModel. The TraceID, SpanID and parent
+// SpanID are converted into the hex representation used by wtracing, the span kind is mapped to the corresponding
+// wtracing.Kind, the "service.name" resource attribute determines the local endpoint and the peer attributes of the
+// span (if any) determine the remote endpoint. All span attributes are stored as tags. If the status of the span is
+// an error, the "error" tag is set to the status description (or to "unknown error" if the description is empty).
+func FromReadOnlySpan(span sdktrace.ReadOnlySpan) wtracing.SpanModel {
+	otelSpanCtx := span.SpanContext()
+	sampled := otelSpanCtx.IsSampled()
+
+	spanCtx := wtracing.SpanContext{
+		TraceID: FromTraceID(otelSpanCtx.TraceID()),
+		ID:      FromSpanID(otelSpanCtx.SpanID()),
+		Sampled: &sampled,
+	}
+	if parent := span.Parent(); parent.SpanID().IsValid() {
+		parentID := FromSpanID(parent.SpanID())
+		spanCtx.ParentID = &parentID
+	}
+
+	tags := make(map[string]string)
+	for _, kv := range span.Attributes() {
+		tags[string(kv.Key)] = kv.Value.Emit()
+	}
+	if scope := span.InstrumentationScope(); scope.Name != "" {
+		tags[scopeNameTag] = scope.Name
+		if scope.Version != "" {
+			tags[scopeVersionTag] = scope.Version
+		}
+	}
+	if status := span.Status(); status.Code == codes.Error {
+		tags[statusCodeTag] = status.Code.String()
+		errorVal := status.Description
+		if errorVal == "" {
+			// an empty error tag would be indistinguishable from a span that is not in error for some consumers
+			errorVal = defaultErrorTagValue
+		}
+		tags[wtracing.ErrorTagKey] = errorVal
+	}
+
+	var duration time.Duration
+	if endTime := span.EndTime(); !endTime.IsZero() {
+		duration = endTime.Sub(span.StartTime())
+	}
+
+	return wtracing.SpanModel{
+		SpanContext:    spanCtx,
+		Name:           span.Name(),
+		Kind:           fromSpanKind(span.SpanKind()),
+		Timestamp:      span.StartTime(),
+		Duration:       duration,
+		LocalEndpoint:  localEndpoint(span),
+		RemoteEndpoint: remoteEndpoint(span.Attributes()),
+		Tags:           tags,
+	}
+}
+
+func fromSpanKind(kind trace.SpanKind) wtracing.Kind {
+	switch kind {
+	case trace.SpanKindClient:
+		return wtracing.Client
+	case trace.SpanKindServer:
+		return wtracing.Server
+	case trace.SpanKindProducer:
+		return wtracing.Producer
+	case trace.SpanKindConsumer:
+		return wtracing.Consumer
+	default:
+		return wtracing.Undetermined
+	}
+}
+
+func localEndpoint(span sdktrace.ReadOnlySpan) *wtracing.Endpoint {
+	res := span.Resource()
+	if res == nil {
+		return nil
+	}
+	serviceName, ok := res.Set().Value(serviceNameKey)
+	if !ok {
+		return nil
+	}
+	return &wtracing.Endpoint{
+		ServiceName: serviceName.Emit(),
+	}
+}
+
+func remoteEndpoint(attrs []attribute.KeyValue) *wtracing.Endpoint {
+	attrMap := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, kv := range attrs {
+		attrMap[kv.Key] = kv.Value
+	}
+
+	var endpoint wtracing.Endpoint
+	var found bool
+	for _, key := range []attribute.Key{peerServiceKey, serverAddrKey, netPeerNameKey} {
+		if v, ok := attrMap[key]; ok && v.Emit() != "" {
+			endpoint.ServiceName = v.Emit()
+			found = true
+			break
+		}
+	}
+	for _, key := range []attribute.Key{peerAddressKey, netPeerIPKey, serverAddrKey} {
+		v, ok := attrMap[key]
+		if !ok {
+			continue
+		}
+		if ip := net.ParseIP(v.Emit()); ip != nil {
+			if ip4 := ip.To4(); ip4 != nil {
+				endpoint.IPv4 = ip4
+			} else {
+				endpoint.IPv6 = ip
+			}
+			found = true
+			break
+		}
+	}
+	for _, key := range []attribute.Key{peerPortKey, serverPortKey, netPeerPortKey} {
+		v, ok := attrMap[key]
+		if !ok {
+			continue
+		}
+		if port, err := strconv.ParseUint(v.Emit(), 10, 16); err == nil {
+			endpoint.Port = uint16(port)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &endpoint
+}
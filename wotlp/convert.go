@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotlp
+
+import (
+	"sort"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+const (
+	scopeName = "github.com/palantir/witchcraft-go-tracing"
+
+	serviceNameKey = "service.name"
+	peerServiceKey = "peer.service"
+	peerAddressKey = "network.peer.address"
+	peerPortKey    = "network.peer.port"
+)
+
+// ToResourceSpans converts the provided spans into OTLP ResourceSpans. Spans are grouped by the service name of their
+// local endpoint, which is set as the "service.name" attribute of the resource of each group. Spans that cannot be
+// converted (for example, because their IDs are malformed) are omitted, and an error describing the first such span
+// is returned along with the spans that could be converted.
+func ToResourceSpans(spans []wtracing.SpanModel) ([]*tracepb.ResourceSpans, error) {
+	var firstErr error
+	spansByService := make(map[string][]*tracepb.Span)
+	var serviceNames []string
+	for _, span := range spans {
+		otlpSpan, err := ToSpan(span)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		var serviceName string
+		if span.LocalEndpoint != nil {
+			serviceName = span.LocalEndpoint.ServiceName
+		}
+		if _, ok := spansByService[serviceName]; !ok {
+			serviceNames = append(serviceNames, serviceName)
+		}
+		spansByService[serviceName] = append(spansByService[serviceName], otlpSpan)
+	}
+	sort.Strings(serviceNames)
+
+	resourceSpans := make([]*tracepb.ResourceSpans, 0, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		resource := &resourcepb.Resource{}
+		if serviceName != "" {
+			resource.Attributes = append(resource.Attributes, stringAttribute(serviceNameKey, serviceName))
+		}
+		resourceSpans = append(resourceSpans, &tracepb.ResourceSpans{
+			Resource: resource,
+			ScopeSpans: []*tracepb.ScopeSpans{{
+				Scope: &commonpb.InstrumentationScope{
+					Name: scopeName,
+				},
+				Spans: spansByService[serviceName],
+			}},
+		})
+	}
+	return resourceSpans, firstErr
+}
+
+// ToSpan converts the provided span into an OTLP Span. Tags are converted into string attributes, the remote endpoint
+// is converted into "peer.service", "network.peer.address" and "network.peer.port" attributes and annotations are
+// converted into events. If the span has an "error" tag, the status of the returned span is set to error with the
+// value of the tag as its message.
+func ToSpan(span wtracing.SpanModel) (*tracepb.Span, error) {
+	traceID, err := wtracing.TraceIDBytes(span.TraceID)
+	if err != nil {
+		return nil, err
+	}
+	spanID, err := wtracing.SpanIDBytes(span.ID)
+	if err != nil {
+		return nil, err
+	}
+	var parentSpanID []byte
+	if span.ParentID != nil {
+		parentID, err := wtracing.SpanIDBytes(*span.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		parentSpanID = parentID[:]
+	}
+
+	tagKeys := make([]string, 0, len(span.Tags))
+	for k := range span.Tags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	attributes := make([]*commonpb.KeyValue, 0, len(tagKeys))
+	for _, k := range tagKeys {
+		attributes = append(attributes, stringAttribute(k, span.Tags[k]))
+	}
+	if re := span.RemoteEndpoint; re != nil {
+		if re.ServiceName != "" {
+			attributes = append(attributes, stringAttribute(peerServiceKey, re.ServiceName))
+		}
+		if len(re.IPv4) > 0 {
+			attributes = append(attributes, stringAttribute(peerAddressKey, re.IPv4.String()))
+		} else if len(re.IPv6) > 0 {
+			attributes = append(attributes, stringAttribute(peerAddressKey, re.IPv6.String()))
+		}
+		if re.Port != 0 {
+			attributes = append(attributes, &commonpb.KeyValue{
+				Key:   peerPortKey,
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(re.Port)}},
+			})
+		}
+	}
+
+	var events []*tracepb.Span_Event
+	for _, annotation := range span.Annotations {
+		events = append(events, &tracepb.Span_Event{
+			TimeUnixNano: uint64(annotation.Timestamp.UnixNano()),
+			Name:         annotation.Value,
+		})
+	}
+
+	status := &tracepb.Status{}
+	if errVal, ok := span.Tags[wtracing.ErrorTagKey]; ok {
+		status.Code = tracepb.Status_STATUS_CODE_ERROR
+		status.Message = errVal
+	}
+
+	return &tracepb.Span{
+		TraceId:           traceID[:],
+		SpanId:            spanID[:],
+		ParentSpanId:      parentSpanID,
+		Name:              span.Name,
+		Kind:              toSpanKind(span.Kind),
+		StartTimeUnixNano: uint64(span.Timestamp.UnixNano()),
+		EndTimeUnixNano:   uint64(span.Timestamp.Add(span.Duration).UnixNano()),
+		Attributes:        attributes,
+		Events:            events,
+		Status:            status,
+	}, nil
+}
+
+func toSpanKind(kind wtracing.Kind) tracepb.Span_SpanKind {
+	switch kind {
+	case wtracing.Client:
+		return tracepb.Span_SPAN_KIND_CLIENT
+	case wtracing.Server:
+		return tracepb.Span_SPAN_KIND_SERVER
+	case wtracing.Producer:
+		return tracepb.Span_SPAN_KIND_PRODUCER
+	case wtracing.Consumer:
+		return tracepb.Span_SPAN_KIND_CONSUMER
+	default:
+		return tracepb.Span_SPAN_KIND_INTERNAL
+	}
+}
+
+func stringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotlp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultTimeout       = 5 * time.Second
+	defaultBatchInterval = time.Second
+	defaultBatchSize     = 100
+	defaultMaxBacklog    = 1000
+)
+
+// ReporterOption configures a reporter returned by NewReporter.
+type ReporterOption func(r *httpReporter)
+
+// WithClient sets the HTTP client used to send requests to the collector. Defaults to a client with a 5 second
+// timeout.
+func WithClient(client *http.Client) ReporterOption {
+	return func(r *httpReporter) {
+		r.client = client
+	}
+}
+
+// WithHeader sets a header that is included in every request sent to the collector (for example, for
+// authentication).
+func WithHeader(key, value string) ReporterOption {
+	return func(r *httpReporter) {
+		r.header.Set(key, value)
+	}
+}
+
+// WithBatchSize sets the maximum number of spans sent in a single request. Defaults to 100. Values less than or equal
+// to 0 are ignored.
+func WithBatchSize(n int) ReporterOption {
+	return func(r *httpReporter) {
+		if n > 0 {
+			r.batchSize = n
+		}
+	}
+}
+
+// WithBatchInterval sets the maximum amount of time that a span is buffered before it is sent. Defaults to 1 second.
+// Values less than or equal to 0 are ignored.
+func WithBatchInterval(d time.Duration) ReporterOption {
+	return func(r *httpReporter) {
+		if d > 0 {
+			r.batchInterval = d
+		}
+	}
+}
+
+// WithMaxBacklog sets the maximum number of spans that are buffered while waiting to be sent. Spans reported while
+// the backlog is full are dropped, and the number of dropped spans is logged at most once per batch interval. Defaults
+// to 1000. Values less than or equal to 0 are ignored.
+func WithMaxBacklog(n int) ReporterOption {
+	return func(r *httpReporter) {
+		if n > 0 {
+			r.maxBacklog = n
+		}
+	}
+}
+
+// WithLogger sets the logger used to report errors that occur while sending spans. Defaults to a logger that writes to
+// STDERR.
+func WithLogger(logger *log.Logger) ReporterOption {
+	return func(r *httpReporter) {
+		r.logger = logger
+	}
+}
+
+// NewReporter returns a wtracing.Reporter that sends spans to an OpenTelemetry collector using the OTLP/HTTP protocol
+// with protobuf encoding. The provided URL should be the full URL of the traces endpoint of the collector (for example,
+// "http://localhost:4318/v1/traces"). Spans are buffered and sent asynchronously in batches; closing the reporter
+// sends all buffered spans and blocks until they have been sent.
+func NewReporter(url string, opts ...ReporterOption) wtracing.Reporter {
+	r := &httpReporter{
+		url:           url,
+		client:        &http.Client{Timeout: defaultTimeout},
+		header:        make(http.Header),
+		batchSize:     defaultBatchSize,
+		batchInterval: defaultBatchInterval,
+		maxBacklog:    defaultMaxBacklog,
+		logger:        log.New(os.Stderr, "", log.LstdFlags),
+		quit:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.spanC = make(chan wtracing.SpanModel, r.maxBacklog)
+	go r.loop()
+	return r
+}
+
+type httpReporter struct {
+	url           string
+	client        *http.Client
+	header        http.Header
+	batchSize     int
+	batchInterval time.Duration
+	maxBacklog    int
+	logger        *log.Logger
+
+	spanC chan wtracing.SpanModel
+	quit  chan struct{}
+	done  chan struct{}
+	// dropped is the number of spans that have been dropped because the backlog was full since the last time that
+	// dropped spans were logged.
+	dropped int64
+
+	// closedMutex guards closed: Send holds a read lock while it adds a span to spanC so that every span that is
+	// added before the reporter is closed is sent by the final flush.
+	closedMutex sync.RWMutex
+	closed      bool
+}
+
+func (r *httpReporter) Send(span wtracing.SpanModel) {
+	r.closedMutex.RLock()
+	defer r.closedMutex.RUnlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.spanC <- span:
+	default:
+		atomic.AddInt64(&r.dropped, 1)
+	}
+}
+
+func (r *httpReporter) Close() error {
+	r.closedMutex.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.quit)
+	}
+	r.closedMutex.Unlock()
+	<-r.done
+	return nil
+}
+
+// logDropped logs the number of spans that were dropped since the last time that it was called (if any).
+func (r *httpReporter) logDropped() {
+	if dropped := atomic.SwapInt64(&r.dropped, 0); dropped > 0 {
+		r.logger.Printf("dropped %d span(s): OTLP reporter backlog is full", dropped)
+	}
+}
+
+func (r *httpReporter) loop() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.batchInterval)
+	defer ticker.Stop()
+
+	var batch []wtracing.SpanModel
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.send(batch); err != nil {
+			r.logger.Printf("failed to send %d span(s) to OTLP collector: %v", len(batch), err)
+		}
+		batch = nil
+	}
+	for {
+		select {
+		case span := <-r.spanC:
+			batch = append(batch, span)
+			if len(batch) >= r.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+			r.logDropped()
+		case <-r.quit:
+			// send all spans that were reported before the reporter was closed
+			for {
+				select {
+				case span := <-r.spanC:
+					batch = append(batch, span)
+					if len(batch) >= r.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					r.logDropped()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *httpReporter) send(spans []wtracing.SpanModel) error {
+	resourceSpans, convertErr := ToResourceSpans(spans)
+	if convertErr != nil {
+		r.logger.Printf("dropping span that could not be converted to OTLP: %v", convertErr)
+	}
+	if len(resourceSpans) == 0 {
+		return nil
+	}
+	// TracesData has the same wire format as the ExportTraceServiceRequest message expected by the collector
+	body, err := proto.Marshal(&tracepb.TracesData{
+		ResourceSpans: resourceSpans,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("collector returned status %s", resp.Status)
+	}
+	return nil
+}
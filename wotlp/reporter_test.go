@@ -0,0 +1,306 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wotlp_test
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wotlp"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReporter(t *testing.T) {
+	collector := newTestCollector(t)
+	reporter := wotlp.NewReporter(collector.server.URL+"/v1/traces",
+		wotlp.WithHeader("Authorization", "Bearer token"),
+		wotlp.WithBatchInterval(time.Hour),
+	)
+
+	tracer, err := wzipkin.NewTracer(reporter, wtracing.WithLocalEndpoint(&wtracing.Endpoint{ServiceName: "my-service"}))
+	require.NoError(t, err)
+	parent := tracer.StartSpan("parent", wtracing.WithKind(wtracing.Server))
+	child := tracer.StartSpan("child", wtracing.WithParent(parent), wtracing.WithKind(wtracing.Client), wtracing.WithSpanTag("error", "failed"))
+	child.Finish()
+	parent.Finish()
+	require.NoError(t, reporter.Close())
+
+	requests := collector.requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/v1/traces", requests[0].path)
+	assert.Equal(t, "application/x-protobuf", requests[0].header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", requests[0].header.Get("Authorization"))
+
+	resourceSpans := requests[0].data.ResourceSpans
+	require.Len(t, resourceSpans, 1)
+	resourceAttrs := resourceSpans[0].Resource.Attributes
+	require.Len(t, resourceAttrs, 1)
+	assert.Equal(t, "service.name", resourceAttrs[0].Key)
+	assert.Equal(t, "my-service", resourceAttrs[0].Value.GetStringValue())
+	require.Len(t, resourceSpans[0].ScopeSpans, 1)
+	spans := resourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	childSpan, parentSpan := spans[0], spans[1]
+	assert.Equal(t, "child", childSpan.Name)
+	assert.Equal(t, "parent", parentSpan.Name)
+	assert.Equal(t, parentSpan.TraceId, childSpan.TraceId)
+	assert.Equal(t, parentSpan.SpanId, childSpan.ParentSpanId)
+	assert.Empty(t, parentSpan.ParentSpanId)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_SERVER, parentSpan.Kind)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, childSpan.Kind)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, childSpan.Status.Code)
+	assert.Equal(t, "failed", childSpan.Status.Message)
+}
+
+func TestReporterBatchSize(t *testing.T) {
+	collector := newTestCollector(t)
+	reporter := wotlp.NewReporter(collector.server.URL, wotlp.WithBatchSize(2), wotlp.WithBatchInterval(time.Hour))
+
+	tracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		tracer.StartSpan("span").Finish()
+	}
+	require.NoError(t, reporter.Close())
+
+	var counts []int
+	for _, req := range collector.requests() {
+		counts = append(counts, len(req.data.ResourceSpans[0].ScopeSpans[0].Spans))
+	}
+	assert.Equal(t, []int{2, 2, 1}, counts)
+}
+
+func TestReporterBatchInterval(t *testing.T) {
+	collector := newTestCollector(t)
+	reporter := wotlp.NewReporter(collector.server.URL, wotlp.WithBatchInterval(10*time.Millisecond))
+	defer func() {
+		_ = reporter.Close()
+	}()
+
+	tracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+	tracer.StartSpan("span").Finish()
+
+	assert.Eventually(t, func() bool {
+		return len(collector.requests()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReporterIgnoresInvalidOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opt  wotlp.ReporterOption
+	}{
+		{name: "zero batch size", opt: wotlp.WithBatchSize(0)},
+		{name: "negative batch size", opt: wotlp.WithBatchSize(-1)},
+		{name: "zero batch interval", opt: wotlp.WithBatchInterval(0)},
+		{name: "negative batch interval", opt: wotlp.WithBatchInterval(-time.Second)},
+		{name: "zero max backlog", opt: wotlp.WithMaxBacklog(0)},
+		{name: "negative max backlog", opt: wotlp.WithMaxBacklog(-1)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			collector := newTestCollector(t)
+			// the invalid option is ignored, so all spans are sent in a single batch when the reporter is closed
+			reporter := wotlp.NewReporter(collector.server.URL, wotlp.WithBatchInterval(time.Hour), tc.opt)
+
+			tracer, err := wzipkin.NewTracer(reporter)
+			require.NoError(t, err)
+			for i := 0; i < 5; i++ {
+				tracer.StartSpan("span").Finish()
+			}
+			require.NoError(t, reporter.Close())
+
+			var counts []int
+			for _, req := range collector.requests() {
+				counts = append(counts, len(req.data.ResourceSpans[0].ScopeSpans[0].Spans))
+			}
+			assert.Equal(t, []int{5}, counts)
+		})
+	}
+}
+
+func TestReporterLogsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var logBuf syncBuffer
+	reporter := wotlp.NewReporter(server.URL, wotlp.WithLogger(log.New(&logBuf, "", 0)))
+	reporter.Send(wtracing.SpanModel{
+		SpanContext: wtracing.SpanContext{TraceID: "6c2f558d62a7085f", ID: "6c2f558d62a7085f"},
+		Name:        "span",
+	})
+	require.NoError(t, reporter.Close())
+	assert.Contains(t, logBuf.String(), "503 Service Unavailable")
+}
+
+func TestReporterLogsDroppedSpanCount(t *testing.T) {
+	// the collector blocks until the test completes so that the backlog fills up
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+
+	var logBuf syncBuffer
+	reporter := wotlp.NewReporter(server.URL,
+		wotlp.WithBatchSize(1),
+		wotlp.WithMaxBacklog(1),
+		wotlp.WithBatchInterval(time.Hour),
+		wotlp.WithLogger(log.New(&logBuf, "", 0)),
+	)
+	span := wtracing.SpanModel{
+		SpanContext: wtracing.SpanContext{TraceID: "6c2f558d62a7085f", ID: "6c2f558d62a7085f"},
+		Name:        "span",
+	}
+	// at most 2 spans can be accepted (1 sent to the blocked collector and 1 in the backlog), so the rest are dropped
+	for i := 0; i < 12; i++ {
+		reporter.Send(span)
+	}
+	close(unblock)
+	require.NoError(t, reporter.Close())
+
+	// dropped spans are reported in a single line rather than one line per span
+	assert.Equal(t, 1, strings.Count(logBuf.String(), "dropped"), logBuf.String())
+	assert.Regexp(t, `dropped \d+ span\(s\): OTLP reporter backlog is full`, logBuf.String())
+
+	// spans sent after the reporter is closed are ignored
+	reporter.Send(span)
+}
+
+func TestToSpan(t *testing.T) {
+	parentID := wtracing.SpanID("7a3e447c51b1244b")
+	timestamp := time.Unix(1500000000, 0)
+	span, err := wotlp.ToSpan(wtracing.SpanModel{
+		SpanContext: wtracing.SpanContext{
+			TraceID:  "7a3e447c51b1244b6c2f558d62a7085f",
+			ID:       "6c2f558d62a7085f",
+			ParentID: &parentID,
+		},
+		Name:      "span",
+		Timestamp: timestamp,
+		Duration:  time.Second,
+		RemoteEndpoint: &wtracing.Endpoint{
+			ServiceName: "remote-service",
+			IPv4:        net.ParseIP("10.0.0.1").To4(),
+			Port:        8443,
+		},
+		Annotations: []wtracing.Annotation{{Timestamp: timestamp, Value: "event"}},
+		Tags:        map[string]string{"key": "value"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{0x7a, 0x3e, 0x44, 0x7c, 0x51, 0xb1, 0x24, 0x4b, 0x6c, 0x2f, 0x55, 0x8d, 0x62, 0xa7, 0x08, 0x5f}, span.TraceId)
+	assert.Equal(t, []byte{0x6c, 0x2f, 0x55, 0x8d, 0x62, 0xa7, 0x08, 0x5f}, span.SpanId)
+	assert.Equal(t, []byte{0x7a, 0x3e, 0x44, 0x7c, 0x51, 0xb1, 0x24, 0x4b}, span.ParentSpanId)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_INTERNAL, span.Kind)
+	assert.Equal(t, uint64(timestamp.UnixNano()), span.StartTimeUnixNano)
+	assert.Equal(t, uint64(timestamp.Add(time.Second).UnixNano()), span.EndTimeUnixNano)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_UNSET, span.Status.Code)
+
+	attrs := make(map[string]string)
+	for _, kv := range span.Attributes {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			attrs[kv.Key] = v.StringValue
+		case *commonpb.AnyValue_IntValue:
+			attrs[kv.Key] = strconv.FormatInt(v.IntValue, 10)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"key":                  "value",
+		"peer.service":         "remote-service",
+		"network.peer.address": "10.0.0.1",
+		"network.peer.port":    "8443",
+	}, attrs)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "event", span.Events[0].Name)
+
+	_, err = wotlp.ToSpan(wtracing.SpanModel{SpanContext: wtracing.SpanContext{TraceID: "invalid", ID: "6c2f558d62a7085f"}})
+	assert.Error(t, err)
+}
+
+type collectorRequest struct {
+	path   string
+	header http.Header
+	data   *tracepb.TracesData
+}
+
+type testCollector struct {
+	server *httptest.Server
+
+	mutex sync.Mutex
+	reqs  []collectorRequest
+}
+
+func newTestCollector(t *testing.T) *testCollector {
+	c := &testCollector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		data := &tracepb.TracesData{}
+		require.NoError(t, proto.Unmarshal(body, data))
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.reqs = append(c.reqs, collectorRequest{
+			path:   r.URL.Path,
+			header: r.Header,
+			data:   data,
+		})
+	}))
+	t.Cleanup(c.server.Close)
+	return c
+}
+
+func (c *testCollector) requests() []collectorRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]collectorRequest(nil), c.reqs...)
+}
+
+type syncBuffer struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chrometrace converts spans into the Chrome Trace Event format so that traces can be viewed in
+// chrome://tracing or Perfetto (https://ui.perfetto.dev) without running a Zipkin server.
+//
+// Every service (the ServiceName of the LocalEndpoint of a span) is rendered as a separate process track. Trace viewers
+// require the spans on a single thread to be properly nested, so the spans of each service are distributed across as
+// many threads as are needed for the spans on every thread to be nested. The IDs of each span are included in its args,
+// along with its tags (nested under the "tags" arg so that they cannot overwrite the IDs), and annotations are rendered
+// as instant events.
+//
+// The format is described at https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU.
+package chrometrace
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+const (
+	unknownServiceName = "unknown service"
+
+	tagsArgKey = "tags"
+)
+
+// Trace is a trace in the Chrome Trace Event JSON object format.
+type Trace struct {
+	TraceEvents     []Event `json:"traceEvents"`
+	DisplayTimeUnit string  `json:"displayTimeUnit,omitempty"`
+}
+
+// Event is a Chrome trace event. Timestamps and durations are in microseconds.
+type Event struct {
+	Name  string                 `json:"name"`
+	Cat   string                 `json:"cat,omitempty"`
+	Ph    string                 `json:"ph"`
+	Ts    float64                `json:"ts"`
+	Dur   float64                `json:"dur,omitempty"`
+	Pid   int                    `json:"pid"`
+	Tid   int                    `json:"tid"`
+	Scope string                 `json:"s,omitempty"`
+	Args  map[string]interface{} `json:"args,omitempty"`
+}
+
+const (
+	phaseComplete = "X"
+	phaseInstant  = "i"
+	phaseMetadata = "M"
+
+	instantScopeThread = "t"
+)
+
+// Convert returns the Chrome trace for the provided spans. The spans may belong to multiple traces.
+func Convert(spans []wtracing.SpanModel) Trace {
+	byService := make(map[string][]wtracing.SpanModel)
+	for _, span := range spans {
+		serviceName := unknownServiceName
+		if endpoint := span.LocalEndpoint; endpoint != nil && endpoint.ServiceName != "" {
+			serviceName = endpoint.ServiceName
+		}
+		byService[serviceName] = append(byService[serviceName], span)
+	}
+	serviceNames := make([]string, 0, len(byService))
+	for serviceName := range byService {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	sort.Strings(serviceNames)
+
+	trace := Trace{
+		TraceEvents:     []Event{},
+		DisplayTimeUnit: "ms",
+	}
+	for i, serviceName := range serviceNames {
+		pid := i + 1
+		trace.TraceEvents = append(trace.TraceEvents, Event{
+			Name: "process_name",
+			Ph:   phaseMetadata,
+			Pid:  pid,
+			Args: map[string]interface{}{"name": serviceName},
+		})
+		trace.TraceEvents = append(trace.TraceEvents, serviceEvents(pid, byService[serviceName])...)
+	}
+	return trace
+}
+
+// Write writes the Chrome trace for the provided spans to the provided writer as JSON.
+func Write(w io.Writer, spans []wtracing.SpanModel) error {
+	return json.NewEncoder(w).Encode(Convert(spans))
+}
+
+func serviceEvents(pid int, spans []wtracing.SpanModel) []Event {
+	// sort by start time and then by duration (longest first) so that parents are assigned before their children
+	sort.SliceStable(spans, func(i, j int) bool {
+		if !spans[i].Timestamp.Equal(spans[j].Timestamp) {
+			return spans[i].Timestamp.Before(spans[j].Timestamp)
+		}
+		return spans[i].Duration > spans[j].Duration
+	})
+
+	var events []Event
+	var lanes []*lane
+	for _, span := range spans {
+		tid := assignLane(&lanes, span)
+		events = append(events, Event{
+			Name: span.Name,
+			Cat:  category(span.Kind),
+			Ph:   phaseComplete,
+			Ts:   micros(span.Timestamp),
+			Dur:  float64(span.Duration) / float64(time.Microsecond),
+			Pid:  pid,
+			Tid:  tid,
+			Args: args(span),
+		})
+		for _, annotation := range span.Annotations {
+			events = append(events, Event{
+				Name:  annotation.Value,
+				Cat:   "annotation",
+				Ph:    phaseInstant,
+				Ts:    micros(annotation.Timestamp),
+				Pid:   pid,
+				Tid:   tid,
+				Scope: instantScopeThread,
+			})
+		}
+	}
+	return events
+}
+
+// lane is a thread of a process track. ends contains the end times of the spans that are open at the current point of
+// the assignment, innermost last.
+type lane struct {
+	ends []time.Time
+}
+
+// assignLane assigns the provided span to the first lane in which it is nested within the innermost open span (or in
+// which no spans are open) and returns the thread ID of the lane. A new lane is created if no lane is suitable. Spans
+// must be assigned in order of start time.
+func assignLane(lanes *[]*lane, span wtracing.SpanModel) int {
+	start, end := span.Timestamp, span.Timestamp.Add(span.Duration)
+	for i, l := range *lanes {
+		for len(l.ends) > 0 && !l.ends[len(l.ends)-1].After(start) {
+			l.ends = l.ends[:len(l.ends)-1]
+		}
+		if len(l.ends) == 0 || !end.After(l.ends[len(l.ends)-1]) {
+			l.ends = append(l.ends, end)
+			return i + 1
+		}
+	}
+	*lanes = append(*lanes, &lane{ends: []time.Time{end}})
+	return len(*lanes)
+}
+
+func category(kind wtracing.Kind) string {
+	if kind == wtracing.Undetermined {
+		return "span"
+	}
+	return string(kind)
+}
+
+func args(span wtracing.SpanModel) map[string]interface{} {
+	spanArgs := map[string]interface{}{
+		"traceId": span.TraceID,
+		"spanId":  span.ID,
+	}
+	if span.ParentID != nil {
+		spanArgs["parentId"] = *span.ParentID
+	}
+	if endpoint := span.RemoteEndpoint; endpoint != nil && endpoint.ServiceName != "" {
+		spanArgs["remoteService"] = endpoint.ServiceName
+	}
+	if len(span.Tags) > 0 {
+		tags := make(map[string]string, len(span.Tags))
+		for k, v := range span.Tags {
+			tags[k] = v
+		}
+		spanArgs[tagsArgKey] = tags
+	}
+	return spanArgs
+}
+
+func micros(t time.Time) float64 {
+	// convert whole microseconds separately: epoch nanoseconds exceed the precision of a float64
+	return float64(t.UnixMicro()) + float64(t.Nanosecond()%int(time.Microsecond))/float64(time.Microsecond)
+}
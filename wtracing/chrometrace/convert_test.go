@@ -0,0 +1,167 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chrometrace_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/chrometrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Unix(1767225600, 0)
+
+func newSpan(id, service, name string, startMillis, durationMillis int) wtracing.SpanModel {
+	return wtracing.SpanModel{
+		SpanContext: wtracing.SpanContext{
+			TraceID: "0000000000000001",
+			ID:      wtracing.SpanID(id),
+		},
+		Name:          name,
+		Timestamp:     t0.Add(time.Duration(startMillis) * time.Millisecond),
+		Duration:      time.Duration(durationMillis) * time.Millisecond,
+		LocalEndpoint: &wtracing.Endpoint{ServiceName: service},
+	}
+}
+
+func TestConvert(t *testing.T) {
+	parentID := wtracing.SpanID("0000000000000001")
+	server := newSpan("0000000000000001", "frontend", "GET /", 0, 100)
+	server.Kind = wtracing.Server
+	server.Tags = map[string]string{"http.status_code": "200"}
+	// two concurrent children that are not nested within each other must be on different threads
+	child1 := newSpan("0000000000000002", "frontend", "child1", 10, 50)
+	child1.ParentID = &parentID
+	child2 := newSpan("0000000000000003", "frontend", "child2", 20, 50)
+	child2.ParentID = &parentID
+	child2.Annotations = []wtracing.Annotation{{Timestamp: t0.Add(30 * time.Millisecond), Value: "retry"}}
+	backend := newSpan("0000000000000004", "backend", "query", 15, 10)
+	unknown := newSpan("0000000000000005", "", "unknown", 0, 1)
+
+	trace := chrometrace.Convert([]wtracing.SpanModel{child2, backend, server, unknown, child1})
+	assert.Equal(t, chrometrace.Trace{
+		DisplayTimeUnit: "ms",
+		TraceEvents: []chrometrace.Event{
+			{Name: "process_name", Ph: "M", Pid: 1, Args: map[string]interface{}{"name": "backend"}},
+			{Name: "query", Cat: "span", Ph: "X", Ts: 1767225600015000, Dur: 10000, Pid: 1, Tid: 1, Args: map[string]interface{}{
+				"traceId": wtracing.TraceID("0000000000000001"),
+				"spanId":  wtracing.SpanID("0000000000000004"),
+			}},
+			{Name: "process_name", Ph: "M", Pid: 2, Args: map[string]interface{}{"name": "frontend"}},
+			{Name: "GET /", Cat: "SERVER", Ph: "X", Ts: 1767225600000000, Dur: 100000, Pid: 2, Tid: 1, Args: map[string]interface{}{
+				"traceId": wtracing.TraceID("0000000000000001"),
+				"spanId":  wtracing.SpanID("0000000000000001"),
+				"tags":    map[string]string{"http.status_code": "200"},
+			}},
+			{Name: "child1", Cat: "span", Ph: "X", Ts: 1767225600010000, Dur: 50000, Pid: 2, Tid: 1, Args: map[string]interface{}{
+				"traceId":  wtracing.TraceID("0000000000000001"),
+				"spanId":   wtracing.SpanID("0000000000000002"),
+				"parentId": parentID,
+			}},
+			{Name: "child2", Cat: "span", Ph: "X", Ts: 1767225600020000, Dur: 50000, Pid: 2, Tid: 2, Args: map[string]interface{}{
+				"traceId":  wtracing.TraceID("0000000000000001"),
+				"spanId":   wtracing.SpanID("0000000000000003"),
+				"parentId": parentID,
+			}},
+			{Name: "retry", Cat: "annotation", Ph: "i", Ts: 1767225600030000, Pid: 2, Tid: 2, Scope: "t"},
+			{Name: "process_name", Ph: "M", Pid: 3, Args: map[string]interface{}{"name": "unknown service"}},
+			{Name: "unknown", Cat: "span", Ph: "X", Ts: 1767225600000000, Dur: 1000, Pid: 3, Tid: 1, Args: map[string]interface{}{
+				"traceId": wtracing.TraceID("0000000000000001"),
+				"spanId":  wtracing.SpanID("0000000000000005"),
+			}},
+		},
+	}, trace)
+}
+
+func TestConvertReusesLanes(t *testing.T) {
+	trace := chrometrace.Convert([]wtracing.SpanModel{
+		newSpan("0000000000000001", "svc", "a", 0, 10),
+		newSpan("0000000000000002", "svc", "b", 5, 10),
+		// starts after both a and b have finished, so can use the first thread again
+		newSpan("0000000000000003", "svc", "c", 20, 10),
+	})
+	var tids []int
+	for _, event := range trace.TraceEvents[1:] {
+		tids = append(tids, event.Tid)
+	}
+	assert.Equal(t, []int{1, 2, 1}, tids)
+}
+
+func TestConvertTagsDoNotOverwriteIDs(t *testing.T) {
+	span := newSpan("0000000000000001", "svc", "a", 0, 10)
+	span.Tags = map[string]string{"traceId": "tag", "spanId": "tag"}
+
+	trace := chrometrace.Convert([]wtracing.SpanModel{span})
+	require.Len(t, trace.TraceEvents, 2)
+	assert.Equal(t, map[string]interface{}{
+		"traceId": wtracing.TraceID("0000000000000001"),
+		"spanId":  wtracing.SpanID("0000000000000001"),
+		"tags":    map[string]string{"traceId": "tag", "spanId": "tag"},
+	}, trace.TraceEvents[1].Args)
+}
+
+func TestReporter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	reporter := chrometrace.NewReporter(buf)
+	reporter.Send(newSpan("0000000000000001", "svc", "a", 0, 10))
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, reporter.Close())
+	require.NoError(t, reporter.Close())
+
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "ms", got["displayTimeUnit"])
+	assert.Len(t, got["traceEvents"], 2)
+}
+
+func TestReporterDoesNotCloseWriter(t *testing.T) {
+	w := &closeRecordingWriter{}
+	reporter := chrometrace.NewReporter(w)
+	require.NoError(t, reporter.Close())
+	assert.False(t, w.closed)
+	assert.NotEmpty(t, w.String())
+}
+
+func TestFileReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	reporter, err := chrometrace.NewFileReporter(path)
+	require.NoError(t, err)
+	reporter.Send(newSpan("0000000000000001", "svc", "a", 0, 10))
+	require.NoError(t, reporter.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &got))
+	assert.Len(t, got["traceEvents"], 2)
+}
+
+type closeRecordingWriter struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (w *closeRecordingWriter) Close() error {
+	w.closed = true
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chrometrace
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// NewReporter returns a reporter that collects the spans that are sent to it and writes them to the provided writer as
+// a Chrome trace when it is closed. Trace viewers require a complete trace, so all spans are retained in memory until
+// the reporter is closed: the reporter is intended for bounded workloads such as tests, benchmarks or command-line
+// tools rather than long-running services. The provided writer is not closed by the reporter: use NewFileReporter to
+// write the trace to a file that is owned by the reporter.
+func NewReporter(w io.Writer) wtracing.Reporter {
+	return &reporter{
+		w: w,
+	}
+}
+
+// NewFileReporter returns a reporter that behaves like the reporter returned by NewReporter, but writes the trace to a
+// file that it creates (or truncates) at the provided path. The file is closed when the reporter is closed.
+func NewFileReporter(path string) (wtracing.Reporter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &reporter{
+		w:      f,
+		closer: f,
+	}, nil
+}
+
+type reporter struct {
+	w io.Writer
+	// closer is closed after the trace is written if it is non-nil
+	closer io.Closer
+
+	mutex  sync.Mutex
+	spans  []wtracing.SpanModel
+	closed bool
+}
+
+func (r *reporter) Send(span wtracing.SpanModel) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed {
+		return
+	}
+	r.spans = append(r.spans, span)
+}
+
+func (r *reporter) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	err := Write(r.w, r.spans)
+	r.spans = nil
+	if r.closer != nil {
+		if closeErr := r.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
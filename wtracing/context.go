@@ -45,7 +45,8 @@ func ContextWithSpan(ctx context.Context, s Span) context.Context {
 	return context.WithValue(ctx, spanContextKey, s)
 }
 
-// SpanFromContext returns the span stored in the provided context, or nil if no span is stored in the context.
+// SpanFromContext returns the span stored in the provided context, or nil if no span is stored in the context. Returns
+// nil if the current span of the context was set using ContextWithSpanContext.
 func SpanFromContext(ctx context.Context) Span {
 	if s, ok := ctx.Value(spanContextKey).(Span); ok {
 		return s
@@ -53,11 +54,36 @@ func SpanFromContext(ctx context.Context) Span {
 	return nil
 }
 
+// ContextWithSpanContext returns a copy of the provided context with the provided SpanContext set as its current span.
+// This is intended for span information that does not have a local Span (for example, a SpanContext that was extracted
+// from an incoming request): functions that start spans from a context will use the provided SpanContext as the parent
+// of new spans in the same manner as a Span set using ContextWithSpan. Setting a SpanContext replaces any span that was
+// previously set on the context, and vice versa. The Remote field of the SpanContext should be true if it was
+// propagated from another process: tracers that use the shared span model (see WithSharedSpans) only share server
+// spans whose parent is remote.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey, sc)
+}
+
+// SpanContextFromContext returns the SpanContext of the current span of the provided context. If the current span was
+// set using ContextWithSpan, returns the result of calling Context() on that span; if it was set using
+// ContextWithSpanContext, returns the SpanContext that was set. Returns false if no span is stored in the context.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	switch v := ctx.Value(spanContextKey).(type) {
+	case Span:
+		return v.Context(), true
+	case SpanContext:
+		return v, true
+	default:
+		return SpanContext{}, false
+	}
+}
+
 // StartSpanFromContext starts a new span with the provided parameters using the provided tracer and the span
-// information in the provided context. If the context contains a span, the new span will be configured to be a child
-// span of that span (unless any of the user-provided span options overrides this). Returns the newly started span and a
-// copy of the provided context that has the new span set as its current span. Returns a nil span if the provided tracer
-// is nil.
+// information in the provided context. If the context contains a span or SpanContext, the new span will be configured
+// to be a child span of that span (unless any of the user-provided span options overrides this). Returns the newly
+// started span and a copy of the provided context that has the new span set as its current span. Returns a nil span if
+// the provided tracer is nil.
 //
 // This function does not read or set the tracer on the provided context. To start a span new span from the tracer set
 // on a context, call StartSpanFromContext(TracerFromContext(ctx), ctx, spanName, spanOptions).
@@ -65,8 +91,8 @@ func StartSpanFromContext(ctx context.Context, tracer Tracer, spanName string, s
 	if tracer == nil {
 		return nil, ctx
 	}
-	if spanInCtx := SpanFromContext(ctx); spanInCtx != nil {
-		spanOptions = append([]SpanOption{WithParent(spanInCtx)}, spanOptions...)
+	if parentCtx, ok := SpanContextFromContext(ctx); ok {
+		spanOptions = append([]SpanOption{WithParentSpanContext(parentCtx)}, spanOptions...)
 	}
 	newSpan := tracer.StartSpan(spanName, spanOptions...)
 	newCtx := ContextWithSpan(ctx, newSpan)
@@ -74,9 +100,9 @@ func StartSpanFromContext(ctx context.Context, tracer Tracer, spanName string, s
 }
 
 // StartSpanFromTracerInContext starts a new span with the provided parameters using the tracer and the span information
-// in the provided context. If the context contains a span, the new span will be configured to be a child span of that
-// span (unless any of the user-provided span options overrides this). Returns the newly started span and a copy of the
-// provided context that has the new span set as its current span.
+// in the provided context. If the context contains a span or SpanContext, the new span will be configured to be a child
+// span of that span (unless any of the user-provided span options overrides this). Returns the newly started span and a
+// copy of the provided context that has the new span set as its current span.
 //
 // If the context does not contain a tracer, returns a no-op Span and an unmodified version of the provided context. The
 // span returned by this function is always non-nil.
@@ -85,14 +111,78 @@ func StartSpanFromTracerInContext(ctx context.Context, spanName string, spanOpti
 	if tracer == nil {
 		return &noopSpan{}, ctx
 	}
-	if spanInCtx := SpanFromContext(ctx); spanInCtx != nil {
-		spanOptions = append([]SpanOption{WithParent(spanInCtx)}, spanOptions...)
+	if parentCtx, ok := SpanContextFromContext(ctx); ok {
+		spanOptions = append([]SpanOption{WithParentSpanContext(parentCtx)}, spanOptions...)
 	}
 	newSpan := tracer.StartSpan(spanName, spanOptions...)
 	newCtx := ContextWithSpan(ctx, newSpan)
 	return newSpan, newCtx
 }
 
+type baggageContextKeyType string
+
+const baggageContextKey = baggageContextKeyType("wtracing.baggage")
+
+// ContextWithBaggage returns a copy of the provided context with the provided baggage set on it. Baggage consists of
+// key-value pairs that are propagated along with a trace across process boundaries by propagation formats that support
+// it. The provided map is copied, so subsequent modifications to it do not affect the returned context.
+func ContextWithBaggage(ctx context.Context, baggage map[string]string) context.Context {
+	baggageCopy := make(map[string]string, len(baggage))
+	for k, v := range baggage {
+		baggageCopy[k] = v
+	}
+	return context.WithValue(ctx, baggageContextKey, baggageCopy)
+}
+
+// BaggageFromContext returns a copy of the baggage stored in the provided context, or nil if no baggage is stored in
+// the context.
+func BaggageFromContext(ctx context.Context) map[string]string {
+	baggage, ok := ctx.Value(baggageContextKey).(map[string]string)
+	if !ok {
+		return nil
+	}
+	baggageCopy := make(map[string]string, len(baggage))
+	for k, v := range baggage {
+		baggageCopy[k] = v
+	}
+	return baggageCopy
+}
+
+// DetachedContext returns a new context that carries the tracer, current span (or SpanContext) and baggage stored in
+// the provided context but is not derived from it: the returned context is never cancelled and has no deadline,
+// regardless of the state of the provided context. This is intended for asynchronous work started by an operation
+// (such as an HTTP request handler) that should remain part of the same trace but should not be cancelled when the
+// operation completes.
+//
+// Only tracing information is copied. Use context.WithoutCancel if all of the values of the provided context should be
+// retained.
+func DetachedContext(ctx context.Context) context.Context {
+	detachedCtx := context.Background()
+	if tracer := TracerFromContext(ctx); tracer != nil {
+		detachedCtx = ContextWithTracer(detachedCtx, tracer)
+	}
+	if spanVal := ctx.Value(spanContextKey); spanVal != nil {
+		// copy the raw value so that both spans and span contexts are retained as-is
+		detachedCtx = context.WithValue(detachedCtx, spanContextKey, spanVal)
+	}
+	if baggageVal := ctx.Value(baggageContextKey); baggageVal != nil {
+		detachedCtx = context.WithValue(detachedCtx, baggageContextKey, baggageVal)
+	}
+	return detachedCtx
+}
+
+// StartDetachedSpanFromTracerInContext returns a detached copy of the provided context (as returned by DetachedContext)
+// and starts a new span from it using StartSpanFromTracerInContext. If the provided context contains a span, the new
+// span is a child of that span, which allows the background work to be attributed to the operation that started it
+// even if that operation finishes first. Returns the newly started span and the detached context that has the new span
+// set as its current span.
+//
+// If the context does not contain a tracer, returns a no-op Span and the detached context. The span returned by this
+// function is always non-nil.
+func StartDetachedSpanFromTracerInContext(ctx context.Context, spanName string, spanOptions ...SpanOption) (Span, context.Context) {
+	return StartSpanFromTracerInContext(DetachedContext(ctx), spanName, spanOptions...)
+}
+
 type noopSpan struct{}
 
 func (noopSpan) Context() SpanContext {
@@ -103,11 +193,11 @@ func (noopSpan) Finish() {}
 
 func (noopSpan) Tag(string, string) {}
 
-// TraceIDFromContext returns the traceId associated with the span stored in the provided context. Returns an empty
-// string if no span is stored in the context.
+// TraceIDFromContext returns the traceId associated with the span or SpanContext stored in the provided context.
+// Returns an empty string if no span is stored in the context.
 func TraceIDFromContext(ctx context.Context) TraceID {
-	if span := SpanFromContext(ctx); span != nil {
-		return span.Context().TraceID
+	if sc, ok := SpanContextFromContext(ctx); ok {
+		return sc.TraceID
 	}
 	return ""
 }
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetachedContext(t *testing.T) {
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(wtracing.ContextWithTracer(context.Background(), tracer))
+	span, ctx := wtracing.StartSpanFromTracerInContext(ctx, "request")
+	defer span.Finish()
+
+	detachedCtx := wtracing.DetachedContext(ctx)
+	cancel()
+
+	assert.Error(t, ctx.Err())
+	assert.NoError(t, detachedCtx.Err())
+	assert.Equal(t, tracer, wtracing.TracerFromContext(detachedCtx))
+	assert.Equal(t, span, wtracing.SpanFromContext(detachedCtx))
+}
+
+func TestStartDetachedSpanFromTracerInContext(t *testing.T) {
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(wtracing.ContextWithTracer(context.Background(), tracer))
+	span, ctx := wtracing.StartSpanFromTracerInContext(ctx, "request")
+	span.Finish()
+	cancel()
+
+	asyncSpan, asyncCtx := wtracing.StartDetachedSpanFromTracerInContext(ctx, "async")
+	defer asyncSpan.Finish()
+
+	assert.NoError(t, asyncCtx.Err())
+	assert.Equal(t, span.Context().TraceID, asyncSpan.Context().TraceID)
+	require.NotNil(t, asyncSpan.Context().ParentID)
+	assert.Equal(t, span.Context().ID, *asyncSpan.Context().ParentID)
+	assert.Equal(t, asyncSpan, wtracing.SpanFromContext(asyncCtx))
+}
+
+func TestStartDetachedSpanFromTracerInContextNoTracer(t *testing.T) {
+	span, ctx := wtracing.StartDetachedSpanFromTracerInContext(context.Background(), "async")
+	assert.NotNil(t, span)
+	assert.Nil(t, wtracing.SpanFromContext(ctx))
+}
+
+func TestContextWithSpanContext(t *testing.T) {
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter())
+	require.NoError(t, err)
+
+	const idHexVal = "6c2f558d62a7085f"
+	remoteCtx := wtracing.SpanContext{
+		TraceID: idHexVal,
+		ID:      idHexVal,
+	}
+	ctx := wtracing.ContextWithSpanContext(wtracing.ContextWithTracer(context.Background(), tracer), remoteCtx)
+
+	assert.Nil(t, wtracing.SpanFromContext(ctx))
+	assert.Equal(t, wtracing.TraceID(idHexVal), wtracing.TraceIDFromContext(ctx))
+	sc, ok := wtracing.SpanContextFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, remoteCtx, sc)
+
+	t.Run("StartSpanFromContext", func(t *testing.T) {
+		span, spanCtx := wtracing.StartSpanFromContext(ctx, tracer, "child")
+		assert.Equal(t, wtracing.TraceID(idHexVal), span.Context().TraceID)
+		require.NotNil(t, span.Context().ParentID)
+		assert.Equal(t, wtracing.SpanID(idHexVal), *span.Context().ParentID)
+		assert.Equal(t, span, wtracing.SpanFromContext(spanCtx))
+	})
+
+	t.Run("StartSpanFromTracerInContext", func(t *testing.T) {
+		span, spanCtx := wtracing.StartSpanFromTracerInContext(ctx, "child")
+		assert.Equal(t, wtracing.TraceID(idHexVal), span.Context().TraceID)
+		require.NotNil(t, span.Context().ParentID)
+		assert.Equal(t, wtracing.SpanID(idHexVal), *span.Context().ParentID)
+		assert.Equal(t, span, wtracing.SpanFromContext(spanCtx))
+	})
+
+	t.Run("DetachedContext", func(t *testing.T) {
+		sc, ok := wtracing.SpanContextFromContext(wtracing.DetachedContext(ctx))
+		assert.True(t, ok)
+		assert.Equal(t, remoteCtx, sc)
+	})
+}
+
+func TestSpanContextFromContextEmpty(t *testing.T) {
+	_, ok := wtracing.SpanContextFromContext(context.Background())
+	assert.False(t, ok)
+	assert.Equal(t, wtracing.TraceID(""), wtracing.TraceIDFromContext(context.Background()))
+}
+
+func TestContextWithBaggage(t *testing.T) {
+	baggage := map[string]string{"key": "value"}
+	ctx := wtracing.ContextWithBaggage(context.Background(), baggage)
+	baggage["key"] = "modified"
+
+	assert.Equal(t, map[string]string{"key": "value"}, wtracing.BaggageFromContext(ctx))
+	assert.Equal(t, map[string]string{"key": "value"}, wtracing.BaggageFromContext(wtracing.DetachedContext(ctx)))
+	assert.Nil(t, wtracing.BaggageFromContext(context.Background()))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ErrorTagKey is the key of the tag used to record errors on spans.
+const ErrorTagKey = "error"
+
+// Go runs the provided function in a new goroutine inside of a new span started using StartSpanFromTracerInContext. The
+// context provided to the function has the new span set as its current span. If the function returns a non-nil error,
+// the error is recorded as the "error" tag of the span. If the function panics, the panic is recorded as the "error"
+// tag of the span and the span is finished before the panic is propagated.
+func Go(ctx context.Context, spanName string, fn func(ctx context.Context) error, spanOptions ...SpanOption) {
+	go func() {
+		_ = runInSpan(ctx, spanName, fn, spanOptions...)
+	}()
+}
+
+// Group is a collection of goroutines working on subtasks that are part of the same task. Every subtask runs inside of
+// its own span that is a child of the span in the context provided to NewGroup. The semantics of Group mirror those of
+// golang.org/x/sync/errgroup.Group.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	wg      sync.WaitGroup
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup returns a new Group and an associated context derived from ctx. The derived context is cancelled the first
+// time a function passed to Go returns a non-nil error or the first time Wait returns, whichever occurs first.
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{
+		ctx:    ctx,
+		cancel: cancel,
+	}, ctx
+}
+
+// Go runs the provided function in a new goroutine inside of a new span in the same manner as the package-level Go
+// function. The first call to return a non-nil error cancels the group's context and its error will be returned by
+// Wait.
+func (g *Group) Go(spanName string, fn func(ctx context.Context) error, spanOptions ...SpanOption) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := runInSpan(g.ctx, spanName, fn, spanOptions...); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+	}()
+}
+
+// Wait blocks until all function calls from the Go method have returned, then returns the first non-nil error (if
+// any) from them.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
+
+func runInSpan(ctx context.Context, spanName string, fn func(ctx context.Context) error, spanOptions ...SpanOption) (err error) {
+	span, ctx := StartSpanFromTracerInContext(ctx, spanName, spanOptions...)
+	defer func() {
+		if r := recover(); r != nil {
+			span.Tag(ErrorTagKey, fmt.Sprintf("panic: %v", r))
+			span.Finish()
+			panic(r)
+		}
+		if err != nil {
+			span.Tag(ErrorTagKey, err.Error())
+		}
+		span.Finish()
+	}()
+	return fn(ctx)
+}
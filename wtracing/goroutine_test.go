@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGo(t *testing.T) {
+	// the span is finished after the function returns, so wait for the span rather than for the function
+	finished := make(chan struct{}, 1)
+	tracer := &recordingTracer{onFinish: func() { finished <- struct{}{} }}
+	ctx := ContextWithTracer(context.Background(), tracer)
+	parent, ctx := StartSpanFromTracerInContext(ctx, "parent")
+
+	Go(ctx, "task", func(ctx context.Context) error {
+		assert.Equal(t, "task", SpanFromContext(ctx).(*recordingSpan).name)
+		return errors.New("task failed")
+	})
+	<-finished
+
+	spans := tracer.finishedSpans(t, 1)
+	assert.Equal(t, "task", spans[0].name)
+	assert.Equal(t, parent.Context(), spans[0].parent)
+	assert.Equal(t, map[string]string{ErrorTagKey: "task failed"}, spans[0].tags)
+}
+
+func TestGroup(t *testing.T) {
+	tracer := &recordingTracer{}
+	ctx := ContextWithTracer(context.Background(), tracer)
+	parent, ctx := StartSpanFromTracerInContext(ctx, "parent")
+
+	group, groupCtx := NewGroup(ctx)
+	group.Go("success", func(ctx context.Context) error {
+		return nil
+	})
+	group.Go("failure", func(ctx context.Context) error {
+		return errors.New("task failed")
+	})
+	assert.EqualError(t, group.Wait(), "task failed")
+	assert.Error(t, groupCtx.Err())
+
+	spans := tracer.finishedSpans(t, 2)
+	for _, span := range spans {
+		assert.Equal(t, parent.Context(), span.parent)
+		switch span.name {
+		case "success":
+			assert.Empty(t, span.tags)
+		case "failure":
+			assert.Equal(t, map[string]string{ErrorTagKey: "task failed"}, span.tags)
+		default:
+			t.Errorf("unexpected span %s", span.name)
+		}
+	}
+}
+
+func TestRunInSpanPanic(t *testing.T) {
+	tracer := &recordingTracer{}
+	ctx := ContextWithTracer(context.Background(), tracer)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = runInSpan(ctx, "task", func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+
+	spans := tracer.finishedSpans(t, 1)
+	assert.Equal(t, map[string]string{ErrorTagKey: "panic: boom"}, spans[0].tags)
+}
+
+type recordingTracer struct {
+	// onFinish is called after a span is finished if it is non-nil.
+	onFinish func()
+
+	mutex    sync.Mutex
+	nextID   int
+	finished []*recordingSpan
+}
+
+func (t *recordingTracer) StartSpan(name string, options ...SpanOption) Span {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.nextID++
+	impl := FromSpanOptions(options...)
+	span := &recordingSpan{
+		tracer: t,
+		name:   name,
+		ctx: SpanContext{
+			TraceID: "6c2f558d62a7085f",
+			ID:      SpanID(fmt.Sprintf("%016x", t.nextID)),
+		},
+		tags: make(map[string]string),
+	}
+	if impl.ParentSpan != nil {
+		span.parent = *impl.ParentSpan
+	}
+	return span
+}
+
+func (t *recordingTracer) finishedSpans(tt *testing.T, count int) []*recordingSpan {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	require.Len(tt, t.finished, count)
+	return t.finished
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	name   string
+	ctx    SpanContext
+	parent SpanContext
+	tags   map[string]string
+}
+
+func (s *recordingSpan) Context() SpanContext {
+	return s.ctx
+}
+
+func (s *recordingSpan) Tag(key string, value string) {
+	s.tags[key] = value
+}
+
+func (s *recordingSpan) Finish() {
+	s.tracer.mutex.Lock()
+	s.tracer.finished = append(s.tracer.finished, s)
+	s.tracer.mutex.Unlock()
+	if s.tracer.onFinish != nil {
+		s.tracer.onFinish()
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracing
+
+import (
+	"encoding/hex"
+
+	werror "github.com/palantir/witchcraft-go-error"
+)
+
+// TraceIDBytes returns the 128-bit binary representation of the provided TraceID, which must be a 16 or 32 character
+// hex string. 64-bit TraceIDs are stored in the lower 64 bits of the returned value (the upper 64 bits are 0), which
+// is the representation used by propagation and export formats that only support 128-bit trace IDs.
+func TraceIDBytes(traceID TraceID) ([16]byte, error) {
+	var b [16]byte
+	dst := b[:]
+	switch len(traceID) {
+	case 16:
+		dst = b[8:]
+	case 32:
+	default:
+		return b, werror.Error("TraceID must be 16 or 32 hex characters", werror.SafeParam("traceID", string(traceID)))
+	}
+	if _, err := hex.Decode(dst, []byte(traceID)); err != nil {
+		return b, werror.Wrap(err, "TraceID is not valid hex", werror.SafeParam("traceID", string(traceID)))
+	}
+	return b, nil
+}
+
+// SpanIDBytes returns the 64-bit binary representation of the provided SpanID, which must be a 16 character hex string.
+func SpanIDBytes(spanID SpanID) ([8]byte, error) {
+	var b [8]byte
+	if len(spanID) != 16 {
+		return b, werror.Error("SpanID must be 16 hex characters", werror.SafeParam("spanID", string(spanID)))
+	}
+	if _, err := hex.Decode(b[:], []byte(spanID)); err != nil {
+		return b, werror.Wrap(err, "SpanID is not valid hex", werror.SafeParam("spanID", string(spanID)))
+	}
+	return b, nil
+}
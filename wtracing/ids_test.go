@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracing_test
+
+import (
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceIDBytes(t *testing.T) {
+	b, err := wtracing.TraceIDBytes("6c2f558d62a7085f")
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte{8: 0x6c, 0x2f, 0x55, 0x8d, 0x62, 0xa7, 0x08, 0x5f}, b)
+
+	b, err = wtracing.TraceIDBytes("7a3e447c51b1244b6C2F558D62A7085F")
+	require.NoError(t, err)
+	assert.Equal(t, [16]byte{0x7a, 0x3e, 0x44, 0x7c, 0x51, 0xb1, 0x24, 0x4b, 0x6c, 0x2f, 0x55, 0x8d, 0x62, 0xa7, 0x08, 0x5f}, b)
+
+	for _, invalid := range []wtracing.TraceID{"", "abc", "6c2f558d62a7085f00", "zzzzzzzzzzzzzzzz"} {
+		_, err := wtracing.TraceIDBytes(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSpanIDBytes(t *testing.T) {
+	b, err := wtracing.SpanIDBytes("6c2f558d62a7085f")
+	require.NoError(t, err)
+	assert.Equal(t, [8]byte{0x6c, 0x2f, 0x55, 0x8d, 0x62, 0xa7, 0x08, 0x5f}, b)
+
+	for _, invalid := range []wtracing.SpanID{"", "6c2f558d62a7085f00", "zzzzzzzzzzzzzzzz"} {
+		_, err := wtracing.SpanIDBytes(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
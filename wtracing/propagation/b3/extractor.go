@@ -28,25 +28,33 @@ import (
 // will be non-nil and will contain an error that describes why the values were invalid. However, even if the "Err"
 // field is set, all of the values that could be extracted from the header and set on the returned context.
 func SpanExtractor(req *http.Request) wtracing.SpanExtractor {
+	return HeaderSpanExtractor(req.Header)
+}
+
+// HeaderSpanExtractor returns a SpanExtractor that returns a wtracing.SpanContext based on the content of the provided
+// http.Header. The values are extracted in the same manner as SpanExtractor.
+func HeaderSpanExtractor(header http.Header) wtracing.SpanExtractor {
 	return func() wtracing.SpanContext {
-		var sc wtracing.SpanContext
+		sc := wtracing.SpanContext{
+			Remote: true,
+		}
 		var errMsgs []string
 		errSafeParams := make(map[string]interface{})
 
-		traceID := strings.ToLower(req.Header.Get(b3TraceID))
+		traceID := strings.ToLower(header.Get(b3TraceID))
 		if traceID == "" {
 			errMsgs = append(errMsgs, "TraceID missing")
 		}
 		sc.TraceID = wtracing.TraceID(traceID)
 
-		spanID := strings.ToLower(req.Header.Get(b3SpanID))
+		spanID := strings.ToLower(header.Get(b3SpanID))
 		if spanID == "" {
 			errMsgs = append(errMsgs, "SpanID missing")
 		}
 		sc.ID = wtracing.SpanID(spanID)
 
 		var parentIDVal *wtracing.SpanID
-		if parentID := strings.ToLower(req.Header.Get(b3ParentSpanID)); parentID != "" {
+		if parentID := strings.ToLower(header.Get(b3ParentSpanID)); parentID != "" {
 			if traceID == "" || spanID == "" {
 				if traceID == "" && spanID == "" {
 					errMsgs = append(errMsgs, "ParentID present but TraceID and SpanID missing")
@@ -61,7 +69,7 @@ func SpanExtractor(req *http.Request) wtracing.SpanExtractor {
 		sc.ParentID = parentIDVal
 
 		var sampledVal *bool
-		switch sampledHeader := strings.ToLower(req.Header.Get(b3Sampled)); sampledHeader {
+		switch sampledHeader := strings.ToLower(header.Get(b3Sampled)); sampledHeader {
 		case falseHeaderVal, "false":
 			boolVal := false
 			sampledVal = &boolVal
@@ -74,7 +82,7 @@ func SpanExtractor(req *http.Request) wtracing.SpanExtractor {
 			errMsgs = append(errMsgs, "Sampled invalid")
 			errSafeParams["sampledHeaderVal"] = sampledHeader
 		}
-		debug := req.Header.Get(b3Flags) == trueHeaderVal
+		debug := header.Get(b3Flags) == trueHeaderVal
 		if debug {
 			sampledVal = nil
 		}
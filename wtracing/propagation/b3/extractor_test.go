@@ -153,6 +153,10 @@ func TestSpanExtractor(t *testing.T) {
 			gotErr := got.Err
 			got.Err = nil
 
+			// extracted contexts are always remote
+			assert.True(t, got.Remote, "Case %d", i)
+			got.Remote = false
+
 			// verify structs are equal
 			assert.Equal(t, tc.want, got, "Case %d", i)
 			// verify errors are equal
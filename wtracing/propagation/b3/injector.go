@@ -26,23 +26,29 @@ import (
 // will be set, but the sampled header will not be. If the provided span is not in debug mode, then the sampled header
 // will explicitly be set to "0" or "1".
 func SpanInjector(req *http.Request) wtracing.SpanInjector {
+	return HeaderSpanInjector(req.Header)
+}
+
+// HeaderSpanInjector returns a SpanInjector that injects a wtracing.SpanContext in the provided http.Header. The values
+// are injected in the same manner as SpanInjector.
+func HeaderSpanInjector(header http.Header) wtracing.SpanInjector {
 	return func(sc wtracing.SpanContext) {
 		if len(sc.TraceID) > 0 && len(sc.ID) > 0 {
-			req.Header.Set(b3TraceID, string(sc.TraceID))
-			req.Header.Set(b3SpanID, string(sc.ID))
+			header.Set(b3TraceID, string(sc.TraceID))
+			header.Set(b3SpanID, string(sc.ID))
 			if parentID := sc.ParentID; parentID != nil {
-				req.Header.Set(b3ParentSpanID, string(*sc.ParentID))
+				header.Set(b3ParentSpanID, string(*sc.ParentID))
 			}
 		}
 
 		if sc.Debug {
-			req.Header.Set(b3Flags, trueHeaderVal)
+			header.Set(b3Flags, trueHeaderVal)
 		} else if sampled := sc.Sampled; sampled != nil {
 			sampledVal := falseHeaderVal
 			if *sampled {
 				sampledVal = trueHeaderVal
 			}
-			req.Header.Set(b3Sampled, sampledVal)
+			header.Set(b3Sampled, sampledVal)
 		}
 	}
 }
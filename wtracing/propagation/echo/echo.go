@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package echo writes the span information of the span handling a request into the headers of the response so that the
+// caller of a service can find the trace of their request. This is intended for use on the server side: the injectors
+// in the propagation packages such as b3 set headers on outgoing requests, while the functions in this package set
+// headers on the http.ResponseWriter of incoming requests.
+package echo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+const (
+	// DefaultTraceIDHeader is the response header that the trace ID is written to if WithTraceIDHeader is not
+	// specified.
+	DefaultTraceIDHeader = "X-B3-TraceId"
+
+	falseHeaderVal = "0"
+	trueHeaderVal  = "1"
+)
+
+type config struct {
+	traceIDHeader string
+	spanIDHeader  string
+	sampledHeader string
+}
+
+// Option configures the headers that are written by the functions in this package.
+type Option func(cfg *config)
+
+// WithTraceIDHeader sets the name of the response header that the trace ID is written to. If the name is empty, the
+// trace ID is not written.
+func WithTraceIDHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.traceIDHeader = name
+	}
+}
+
+// WithSpanIDHeader configures the span ID to be written to the response header with the provided name. The span ID is
+// not written by default.
+func WithSpanIDHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.spanIDHeader = name
+	}
+}
+
+// WithSampledHeader configures the sampled flag to be written to the response header with the provided name as "1" or
+// "0". Debug spans are written as sampled. The sampled flag is not written by default.
+func WithSampledHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.sampledHeader = name
+	}
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		traceIDHeader: DefaultTraceIDHeader,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// ResponseSpanInjector returns a SpanInjector that writes the information of a wtracing.SpanContext to the headers of
+// the provided http.ResponseWriter. By default, only the trace ID is written: the provided options can be used to
+// change the header names and to write the span ID and sampled flag as well. Values that are empty in the SpanContext
+// are not written, and the sampled flag is not written if the sampling decision is undetermined.
+//
+// Headers must be injected before the response status is written (before the first call to WriteHeader or Write on the
+// http.ResponseWriter): headers that are set after this point are not sent to the client.
+func ResponseSpanInjector(w http.ResponseWriter, opts ...Option) wtracing.SpanInjector {
+	cfg := newConfig(opts)
+	return func(sc wtracing.SpanContext) {
+		cfg.inject(w.Header(), sc)
+	}
+}
+
+func (cfg *config) inject(header http.Header, sc wtracing.SpanContext) {
+	if cfg.traceIDHeader != "" && sc.TraceID != "" {
+		header.Set(cfg.traceIDHeader, string(sc.TraceID))
+	}
+	if cfg.spanIDHeader != "" && sc.ID != "" {
+		header.Set(cfg.spanIDHeader, string(sc.ID))
+	}
+	if cfg.sampledHeader != "" {
+		if sc.Debug {
+			header.Set(cfg.sampledHeader, trueHeaderVal)
+		} else if sampled := sc.Sampled; sampled != nil {
+			sampledVal := falseHeaderVal
+			if *sampled {
+				sampledVal = trueHeaderVal
+			}
+			header.Set(cfg.sampledHeader, sampledVal)
+		}
+	}
+}
+
+// InjectFromContext writes the information of the current span of the provided context to the headers of the provided
+// http.ResponseWriter in the manner described by ResponseSpanInjector. Does nothing if the context does not contain a
+// span or SpanContext.
+func InjectFromContext(ctx context.Context, w http.ResponseWriter, opts ...Option) {
+	if sc, ok := wtracing.SpanContextFromContext(ctx); ok {
+		ResponseSpanInjector(w, opts...)(sc)
+	}
+}
+
+// NewHandler returns an http.Handler that writes the information of the current span of the request context to the
+// response headers (as described by InjectFromContext) and then calls the provided handler. The returned handler should
+// be wrapped by the server-side tracing middleware that starts the span for the request so that the span is set on the
+// request context by the time that the returned handler is called.
+func NewHandler(next http.Handler, opts ...Option) http.Handler {
+	cfg := newConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if sc, ok := wtracing.SpanContextFromContext(req.Context()); ok {
+			cfg.inject(w.Header(), sc)
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package echo_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/propagation/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseSpanInjector(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		sc         wtracing.SpanContext
+		opts       []echo.Option
+		wantHeader http.Header
+	}{
+		{
+			name: "trace ID only by default",
+			sc: wtracing.SpanContext{
+				TraceID: "463ac35c9f6413ad",
+				ID:      "72485a3953bb6124",
+				Sampled: boolPtr(true),
+			},
+			wantHeader: http.Header{
+				"X-B3-Traceid": []string{"463ac35c9f6413ad"},
+			},
+		},
+		{
+			name: "custom header names with span ID and sampled",
+			sc: wtracing.SpanContext{
+				TraceID: "463ac35c9f6413ad",
+				ID:      "72485a3953bb6124",
+				Sampled: boolPtr(false),
+			},
+			opts: []echo.Option{
+				echo.WithTraceIDHeader("X-Trace-Id"),
+				echo.WithSpanIDHeader("X-Span-Id"),
+				echo.WithSampledHeader("X-Trace-Sampled"),
+			},
+			wantHeader: http.Header{
+				"X-Trace-Id":      []string{"463ac35c9f6413ad"},
+				"X-Span-Id":       []string{"72485a3953bb6124"},
+				"X-Trace-Sampled": []string{"0"},
+			},
+		},
+		{
+			name: "debug is written as sampled",
+			sc: wtracing.SpanContext{
+				TraceID: "463ac35c9f6413ad",
+				ID:      "72485a3953bb6124",
+				Debug:   true,
+			},
+			opts: []echo.Option{
+				echo.WithSampledHeader("X-Trace-Sampled"),
+			},
+			wantHeader: http.Header{
+				"X-B3-Traceid":    []string{"463ac35c9f6413ad"},
+				"X-Trace-Sampled": []string{"1"},
+			},
+		},
+		{
+			name: "empty values and undetermined sampling are not written",
+			sc:   wtracing.SpanContext{},
+			opts: []echo.Option{
+				echo.WithSpanIDHeader("X-Span-Id"),
+				echo.WithSampledHeader("X-Trace-Sampled"),
+			},
+			wantHeader: http.Header{},
+		},
+		{
+			name: "trace ID not written if header name is empty",
+			sc: wtracing.SpanContext{
+				TraceID: "463ac35c9f6413ad",
+				ID:      "72485a3953bb6124",
+			},
+			opts: []echo.Option{
+				echo.WithTraceIDHeader(""),
+				echo.WithSpanIDHeader("X-Span-Id"),
+			},
+			wantHeader: http.Header{
+				"X-Span-Id": []string{"72485a3953bb6124"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			echo.ResponseSpanInjector(w, tc.opts...)(tc.sc)
+			assert.Equal(t, tc.wantHeader, w.Header())
+		})
+	}
+}
+
+func TestInjectFromContext(t *testing.T) {
+	w := httptest.NewRecorder()
+	echo.InjectFromContext(context.Background(), w)
+	assert.Empty(t, w.Header())
+
+	ctx := wtracing.ContextWithSpanContext(context.Background(), wtracing.SpanContext{
+		TraceID: "463ac35c9f6413ad",
+		ID:      "72485a3953bb6124",
+	})
+	echo.InjectFromContext(ctx, w)
+	assert.Equal(t, "463ac35c9f6413ad", w.Header().Get("X-B3-TraceId"))
+}
+
+func TestNewHandler(t *testing.T) {
+	handler := echo.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}), echo.WithSpanIDHeader("X-Span-Id"))
+
+	// simulate server-side tracing middleware that sets the span for the request on its context
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := wtracing.ContextWithSpanContext(req.Context(), wtracing.SpanContext{
+			TraceID: "463ac35c9f6413ad",
+			ID:      "72485a3953bb6124",
+		})
+		handler.ServeHTTP(w, req.WithContext(ctx))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "463ac35c9f6413ad", resp.Header.Get("X-B3-TraceId"))
+	assert.Equal(t, "72485a3953bb6124", resp.Header.Get("X-Span-Id"))
+}
+
+func boolPtr(in bool) *bool {
+	return &in
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	werror "github.com/palantir/witchcraft-go-error"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// SpanExtractor returns a SpanExtractor that returns a wtracing.SpanContext based on the "uber-trace-id" header of the
+// provided *http.Request.
+func SpanExtractor(req *http.Request) wtracing.SpanExtractor {
+	return HeaderSpanExtractor(req.Header)
+}
+
+// HeaderSpanExtractor returns a SpanExtractor that returns a wtracing.SpanContext based on the "uber-trace-id" header
+// in the provided http.Header. The header has the form "{trace-id}:{span-id}:{parent-span-id}:{flags}". IDs are
+// normalized to 16 (or, for 128-bit trace IDs, 32) lowercase hex characters, and a parent span ID of "0" indicates
+// that there is no parent. If the debug flag is set, the returned context is in debug mode and its "Sampled" field is
+// nil; otherwise, the "Sampled" field is set based on the sampled flag.
+//
+// If the header is missing or malformed, the "Err" field of the returned SpanContext will be non-nil and will contain
+// an error that describes why the value was invalid.
+func HeaderSpanExtractor(header http.Header) wtracing.SpanExtractor {
+	return func() wtracing.SpanContext {
+		sc := wtracing.SpanContext{
+			Remote: true,
+		}
+
+		headerVal := header.Get(traceIDHeader)
+		if headerVal == "" {
+			sc.Err = werror.Error("uber-trace-id header missing")
+			return sc
+		}
+		if unescaped, err := url.QueryUnescape(headerVal); err == nil {
+			headerVal = unescaped
+		}
+
+		parts := strings.Split(headerVal, ":")
+		if len(parts) != 4 {
+			sc.Err = werror.Error("uber-trace-id header must have 4 parts", werror.SafeParam("headerVal", headerVal))
+			return sc
+		}
+
+		var errMsgs []string
+		if traceID, ok := normalizeID(parts[0], 32); ok {
+			if len(traceID) > 16 {
+				// 128-bit trace ID: use the 64-bit representation if the upper 64 bits are 0
+				traceID = strings.TrimPrefix(padID(traceID, 32), "0000000000000000")
+			}
+			sc.TraceID = wtracing.TraceID(padID(traceID, 16))
+		} else {
+			errMsgs = append(errMsgs, "TraceID invalid")
+		}
+		if spanID, ok := normalizeID(parts[1], 16); ok {
+			sc.ID = wtracing.SpanID(padID(spanID, 16))
+		} else {
+			errMsgs = append(errMsgs, "SpanID invalid")
+		}
+		if parentID, ok := normalizeID(parts[2], 16); ok {
+			if strings.Trim(parentID, "0") != "" {
+				parentIDVal := wtracing.SpanID(padID(parentID, 16))
+				sc.ParentID = &parentIDVal
+			}
+		} else {
+			errMsgs = append(errMsgs, "ParentID invalid")
+		}
+		if flags, err := strconv.ParseUint(parts[3], 16, 8); err == nil {
+			if flags&flagDebug != 0 {
+				sc.Debug = true
+			} else {
+				sampled := flags&flagSampled != 0
+				sc.Sampled = &sampled
+			}
+		} else {
+			errMsgs = append(errMsgs, "Flags invalid")
+		}
+
+		if len(errMsgs) > 0 {
+			sc.Err = werror.Error(strings.Join(errMsgs, "; "), werror.SafeParam("headerVal", headerVal))
+		}
+		return sc
+	}
+}
+
+// ExtractBaggage returns the baggage stored in the "uberctx-{key}" headers of the provided http.Header. Keys are
+// lowercased and values are unescaped using URL query escaping (as done by Jaeger clients). Returns nil if the header
+// does not contain any baggage.
+func ExtractBaggage(header http.Header) map[string]string {
+	var baggage map[string]string
+	for k, v := range header {
+		if len(v) == 0 || len(k) <= len(baggageHeaderPrefix) || !strings.EqualFold(k[:len(baggageHeaderPrefix)], baggageHeaderPrefix) {
+			continue
+		}
+		value := v[0]
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		if baggage == nil {
+			baggage = make(map[string]string)
+		}
+		baggage[strings.ToLower(k[len(baggageHeaderPrefix):])] = value
+	}
+	return baggage
+}
+
+// normalizeID returns the lowercase version of the provided hex ID if it is a valid hex string that has at most maxLen
+// characters.
+func normalizeID(id string, maxLen int) (string, bool) {
+	if id == "" || len(id) > maxLen {
+		return "", false
+	}
+	id = strings.ToLower(id)
+	for _, c := range id {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return "", false
+		}
+	}
+	return id, true
+}
+
+// padID left-pads the provided ID with zeros so that it is at least n characters long.
+func padID(id string, n int) string {
+	if len(id) >= n {
+		return id
+	}
+	return strings.Repeat("0", n-len(id)) + id
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger_test
+
+import (
+	"net/http"
+	"testing"
+
+	werror "github.com/palantir/witchcraft-go-error"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/propagation/jaeger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	idHexVal      = "6c2f558d62a7085f"
+	otherIDHexVal = "7a3e447c51b1244b"
+)
+
+func TestSpanExtractor(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		headerVal string
+		want      wtracing.SpanContext
+	}{
+		{
+			name:      "Values extracted",
+			headerVal: idHexVal + ":" + idHexVal + ":" + otherIDHexVal + ":1",
+			want: wtracing.SpanContext{
+				TraceID:  idHexVal,
+				ID:       idHexVal,
+				ParentID: (*wtracing.SpanID)(strPtr(otherIDHexVal)),
+				Sampled:  boolPtr(true),
+			},
+		},
+		{
+			name:      "Zero parent ID and unsampled",
+			headerVal: idHexVal + ":" + idHexVal + ":0:0",
+			want: wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(false),
+			},
+		},
+		{
+			name:      "Debug flag",
+			headerVal: idHexVal + ":" + idHexVal + ":0:3",
+			want: wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Debug:   true,
+			},
+		},
+		{
+			name:      "Short IDs are padded and uppercase is normalized",
+			headerVal: "ABC:1f:0:1",
+			want: wtracing.SpanContext{
+				TraceID: "0000000000000abc",
+				ID:      "000000000000001f",
+				Sampled: boolPtr(true),
+			},
+		},
+		{
+			name:      "128-bit TraceID",
+			headerVal: "1" + otherIDHexVal + ":" + idHexVal + ":0:1",
+			want: wtracing.SpanContext{
+				TraceID: "0000000000000001" + otherIDHexVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+			},
+		},
+		{
+			name:      "128-bit TraceID with zero upper bits",
+			headerVal: "0000000000000000" + otherIDHexVal + ":" + idHexVal + ":0:1",
+			want: wtracing.SpanContext{
+				TraceID: otherIDHexVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+			},
+		},
+		{
+			name:      "URL-encoded header",
+			headerVal: idHexVal + "%3A" + idHexVal + "%3A0%3A1",
+			want: wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+			},
+		},
+		{
+			name: "Error if header missing",
+			want: wtracing.SpanContext{
+				Err: werror.Error("uber-trace-id header missing"),
+			},
+		},
+		{
+			name:      "Error if wrong number of parts",
+			headerVal: idHexVal + ":" + idHexVal,
+			want: wtracing.SpanContext{
+				Err: werror.Error("uber-trace-id header must have 4 parts", werror.SafeParam("headerVal", idHexVal+":"+idHexVal)),
+			},
+		},
+		{
+			name:      "Error if values invalid",
+			headerVal: "xyz:" + idHexVal + "00:0:z",
+			want: wtracing.SpanContext{
+				Err: werror.Error("TraceID invalid; SpanID invalid; Flags invalid", werror.SafeParam("headerVal", "xyz:"+idHexVal+"00:0:z")),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "localhost", nil)
+			require.NoError(t, err)
+			if tc.headerVal != "" {
+				req.Header.Set("uber-trace-id", tc.headerVal)
+			}
+			got := jaeger.SpanExtractor(req)()
+
+			// store Err field and set original values to nil so that comparison occurs without the error
+			wantErr := tc.want.Err
+			tc.want.Err = nil
+			gotErr := got.Err
+			got.Err = nil
+
+			// extracted contexts are always remote
+			assert.True(t, got.Remote, "Case %d", i)
+			got.Remote = false
+
+			// verify structs are equal
+			assert.Equal(t, tc.want, got, "Case %d", i)
+			// verify errors are equal
+			werrorsEqual(t, wantErr, gotErr)
+		})
+	}
+}
+
+func TestExtractBaggage(t *testing.T) {
+	header := http.Header{}
+	header.Set("uberctx-User-Id", "alice%20smith")
+	header.Set("uberctx-tenant", "acme")
+	header.Set("uberctx-greeting", "hello+world+1")
+	header.Set("X-Other", "value")
+	assert.Equal(t, map[string]string{
+		"user-id":  "alice smith",
+		"tenant":   "acme",
+		"greeting": "hello world 1",
+	}, jaeger.ExtractBaggage(header))
+
+	assert.Nil(t, jaeger.ExtractBaggage(http.Header{}))
+}
+
+func werrorsEqual(t *testing.T, wantErr, gotErr error) {
+	if wantErr == nil && gotErr == nil {
+		return
+	} else if wantErr == nil || gotErr == nil {
+		assert.Equal(t, wantErr, gotErr)
+		return
+	}
+
+	assert.Equal(t, wantErr.Error(), gotErr.Error(), "Error messages not equal")
+
+	safeParams1, unsafeParams1 := werror.ParamsFromError(wantErr)
+	safeParams2, unsafeParams2 := werror.ParamsFromError(gotErr)
+
+	assert.Equal(t, safeParams1, safeParams2, "SafeParams not equal")
+	assert.Equal(t, unsafeParams1, unsafeParams2, "UnsafeParams not equal")
+}
+
+func strPtr(in string) *string {
+	return &in
+}
+
+func boolPtr(in bool) *bool {
+	return &in
+}
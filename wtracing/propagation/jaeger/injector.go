@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// SpanInjector returns a SpanInjector that injects a wtracing.SpanContext in the "uber-trace-id" header of the provided
+// *http.Request.
+func SpanInjector(req *http.Request) wtracing.SpanInjector {
+	return HeaderSpanInjector(req.Header)
+}
+
+// HeaderSpanInjector returns a SpanInjector that injects a wtracing.SpanContext in the "uber-trace-id" header of the
+// provided http.Header. The header is only set if both the TraceID and SpanID are non-empty. If the ParentID is nil,
+// the parent span ID is set to "0". If the provided span is in debug mode, both the debug and sampled flags are set
+// (Jaeger requires debug spans to be sampled); otherwise, the sampled flag is set if the span is sampled. Jaeger does
+// not support deferred sampling decisions, so a span with a nil "Sampled" field is injected as not sampled.
+func HeaderSpanInjector(header http.Header) wtracing.SpanInjector {
+	return func(sc wtracing.SpanContext) {
+		if len(sc.TraceID) == 0 || len(sc.ID) == 0 {
+			return
+		}
+		parentID := "0"
+		if sc.ParentID != nil {
+			parentID = string(*sc.ParentID)
+		}
+		var flags uint8
+		if sc.Debug {
+			flags = flagDebug | flagSampled
+		} else if sc.Sampled != nil && *sc.Sampled {
+			flags = flagSampled
+		}
+		header.Set(traceIDHeader, fmt.Sprintf("%s:%s:%s:%x", sc.TraceID, sc.ID, parentID, flags))
+	}
+}
+
+// InjectBaggage sets an "uberctx-{key}" header in the provided http.Header for every entry in the provided baggage.
+// Values are escaped using URL query escaping (as done by Jaeger clients).
+func InjectBaggage(header http.Header, baggage map[string]string) {
+	for k, v := range baggage {
+		header.Set(baggageHeaderPrefix+k, url.QueryEscape(v))
+	}
+}
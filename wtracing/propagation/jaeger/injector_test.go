@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/propagation/jaeger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpanInjector(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		sc            wtracing.SpanContext
+		wantHeaderVal string
+	}{
+		{
+			name: "full span context injection",
+			sc: wtracing.SpanContext{
+				TraceID:  idHexVal,
+				ID:       idHexVal,
+				ParentID: (*wtracing.SpanID)(strPtr(otherIDHexVal)),
+				Sampled:  boolPtr(true),
+			},
+			wantHeaderVal: idHexVal + ":" + idHexVal + ":" + otherIDHexVal + ":1",
+		},
+		{
+			name: "root span that is not sampled",
+			sc: wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(false),
+			},
+			wantHeaderVal: idHexVal + ":" + idHexVal + ":0:0",
+		},
+		{
+			name: "debug span sets debug and sampled flags",
+			sc: wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Debug:   true,
+			},
+			wantHeaderVal: idHexVal + ":" + idHexVal + ":0:3",
+		},
+		{
+			name: "nothing injected for invalid span",
+			sc: wtracing.SpanContext{
+				TraceID: idHexVal,
+				Sampled: boolPtr(true),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "", nil)
+			require.NoError(t, err)
+			jaeger.SpanInjector(req)(tc.sc)
+			assert.Equal(t, tc.wantHeaderVal, req.Header.Get("uber-trace-id"))
+
+			if tc.wantHeaderVal != "" {
+				// injected values should round-trip
+				want := tc.sc
+				want.Remote = true
+				assert.Equal(t, want, jaeger.SpanExtractor(req)())
+			}
+		})
+	}
+}
+
+func TestInjectBaggage(t *testing.T) {
+	header := http.Header{}
+	jaeger.InjectBaggage(header, map[string]string{"user-id": "alice smith"})
+	assert.Equal(t, "alice+smith", header.Get("uberctx-user-id"))
+	assert.Equal(t, map[string]string{"user-id": "alice smith"}, jaeger.ExtractBaggage(header))
+}
+
+func TestBaggageRoundTrip(t *testing.T) {
+	// value encoded by a Jaeger client
+	header := http.Header{}
+	header.Set("uberctx-greeting", "hello+world+1")
+	baggage := jaeger.ExtractBaggage(header)
+	assert.Equal(t, map[string]string{"greeting": "hello world 1"}, baggage)
+
+	injected := http.Header{}
+	jaeger.InjectBaggage(injected, baggage)
+	assert.Equal(t, "hello+world+1", injected.Get("uberctx-greeting"))
+
+	baggage = map[string]string{"value": "a+b c%d/e"}
+	jaeger.InjectBaggage(injected, baggage)
+	assert.Equal(t, "a%2Bb+c%25d%2Fe", injected.Get("uberctx-value"))
+	assert.Equal(t, baggage["value"], jaeger.ExtractBaggage(injected)["value"])
+}
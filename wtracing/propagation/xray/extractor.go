@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"net/http"
+	"strings"
+
+	werror "github.com/palantir/witchcraft-go-error"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// SpanExtractor returns a SpanExtractor that returns a wtracing.SpanContext based on the "X-Amzn-Trace-Id" header of
+// the provided *http.Request.
+func SpanExtractor(req *http.Request) wtracing.SpanExtractor {
+	return HeaderSpanExtractor(req.Header)
+}
+
+// HeaderSpanExtractor returns a SpanExtractor that returns a wtracing.SpanContext based on the "X-Amzn-Trace-Id" header
+// of the provided http.Header. The header has the form "Root=1-xxxxxxxx-xxxxxxxxxxxxxxxxxxxxxxxx;Parent=...;Sampled=1".
+// The root trace ID is converted into a 128-bit TraceID using TraceIDFromRoot, the parent becomes the SpanID and the
+// sampled value ("1", "0" or "?" for a deferred decision) determines the "Sampled" field.
+//
+// Load balancers add a header that has a root trace ID but no parent to requests that do not already have one. In this
+// case, the returned SpanContext only has a TraceID, so spans that use it as their parent become root spans of the
+// trace identified by the header. If the header is missing, has no root trace ID or has invalid values, the "Err"
+// field of the returned SpanContext will be non-nil and will contain an error that describes why the value was
+// invalid.
+func HeaderSpanExtractor(header http.Header) wtracing.SpanExtractor {
+	return func() wtracing.SpanContext {
+		sc := wtracing.SpanContext{
+			Remote: true,
+		}
+
+		headerVal := header.Get(traceHeader)
+		if headerVal == "" {
+			sc.Err = werror.Error("X-Amzn-Trace-Id header missing")
+			return sc
+		}
+
+		var errMsgs []string
+		errSafeParams := map[string]interface{}{
+			"headerVal": headerVal,
+		}
+		fields := make(map[string]string)
+		for _, field := range strings.Split(headerVal, ";") {
+			kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+			if len(kv) == 2 {
+				fields[kv[0]] = kv[1]
+			}
+		}
+
+		if root, ok := fields[rootKey]; !ok {
+			errMsgs = append(errMsgs, "Root missing")
+		} else if traceID, err := TraceIDFromRoot(root); err != nil {
+			errMsgs = append(errMsgs, "Root invalid")
+		} else {
+			sc.TraceID = traceID
+		}
+
+		if parent, ok := fields[parentKey]; ok {
+			parent = strings.ToLower(parent)
+			if len(parent) != 16 || !isHex(parent) {
+				errMsgs = append(errMsgs, "Parent invalid")
+			} else {
+				sc.ID = wtracing.SpanID(parent)
+			}
+		}
+
+		switch sampled := fields[sampledKey]; sampled {
+		case trueHeaderVal:
+			boolVal := true
+			sc.Sampled = &boolVal
+		case falseHeaderVal:
+			boolVal := false
+			sc.Sampled = &boolVal
+		case "", "?":
+			// keep nil
+		default:
+			errMsgs = append(errMsgs, "Sampled invalid")
+		}
+
+		if len(errMsgs) > 0 {
+			sc.Err = werror.Error(strings.Join(errMsgs, "; "), werror.SafeParams(errSafeParams))
+		}
+		return sc
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray_test
+
+import (
+	"net/http"
+	"testing"
+
+	werror "github.com/palantir/witchcraft-go-error"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/propagation/xray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rootVal    = "1-5759e988-bd862e3fe1be46a994272793"
+	traceIDVal = "5759e988bd862e3fe1be46a994272793"
+	idHexVal   = "53995c3f42cd8ad8"
+)
+
+func TestSpanExtractor(t *testing.T) {
+	for i, tc := range []struct {
+		name      string
+		headerVal string
+		want      wtracing.SpanContext
+	}{
+		{
+			name:      "Values extracted",
+			headerVal: "Root=" + rootVal + ";Parent=" + idHexVal + ";Sampled=1",
+			want: wtracing.SpanContext{
+				TraceID: traceIDVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+			},
+		},
+		{
+			name:      "Root only from load balancer",
+			headerVal: "Root=" + rootVal,
+			want: wtracing.SpanContext{
+				TraceID: traceIDVal,
+			},
+		},
+		{
+			name:      "Deferred sampling decision and additional fields",
+			headerVal: "Self=1-67891234-12456789abcdef012345678; Root=" + rootVal + "; Sampled=?; Lineage=a87bd80c:1",
+			want: wtracing.SpanContext{
+				TraceID: traceIDVal,
+			},
+		},
+		{
+			name:      "Not sampled",
+			headerVal: "Root=" + rootVal + ";Parent=" + idHexVal + ";Sampled=0",
+			want: wtracing.SpanContext{
+				TraceID: traceIDVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(false),
+			},
+		},
+		{
+			name: "Error if header missing",
+			want: wtracing.SpanContext{
+				Err: werror.Error("X-Amzn-Trace-Id header missing"),
+			},
+		},
+		{
+			name:      "Error if root missing",
+			headerVal: "Parent=" + idHexVal + ";Sampled=1",
+			want: wtracing.SpanContext{
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+				Err:     werror.Error("Root missing", werror.SafeParam("headerVal", "Parent="+idHexVal+";Sampled=1")),
+			},
+		},
+		{
+			name:      "Error if values invalid",
+			headerVal: "Root=2-5759e988-bd862e3fe1be46a994272793;Parent=xyz;Sampled=yes",
+			want: wtracing.SpanContext{
+				Err: werror.Error("Root invalid; Parent invalid; Sampled invalid", werror.SafeParam("headerVal", "Root=2-5759e988-bd862e3fe1be46a994272793;Parent=xyz;Sampled=yes")),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "localhost", nil)
+			require.NoError(t, err)
+			if tc.headerVal != "" {
+				req.Header.Set("X-Amzn-Trace-Id", tc.headerVal)
+			}
+			got := xray.SpanExtractor(req)()
+
+			// store Err field and set original values to nil so that comparison occurs without the error
+			wantErr := tc.want.Err
+			tc.want.Err = nil
+			gotErr := got.Err
+			got.Err = nil
+
+			// extracted contexts are always remote
+			assert.True(t, got.Remote, "Case %d", i)
+			got.Remote = false
+
+			// verify structs are equal
+			assert.Equal(t, tc.want, got, "Case %d", i)
+			// verify errors are equal
+			werrorsEqual(t, wantErr, gotErr)
+		})
+	}
+}
+
+func TestRootConversion(t *testing.T) {
+	traceID, err := xray.TraceIDFromRoot(rootVal)
+	require.NoError(t, err)
+	assert.Equal(t, wtracing.TraceID(traceIDVal), traceID)
+
+	root, err := xray.RootFromTraceID(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, rootVal, root)
+
+	root, err = xray.RootFromTraceID(idHexVal)
+	require.NoError(t, err)
+	assert.Equal(t, "1-00000000-0000000053995c3f42cd8ad8", root)
+
+	for _, invalid := range []string{"", "1-5759e988", "1-5759e98-bd862e3fe1be46a994272793", "1-5759e988-zd862e3fe1be46a994272793"} {
+		_, err := xray.TraceIDFromRoot(invalid)
+		require.Error(t, err, invalid)
+		safeParams, _ := werror.ParamsFromError(err)
+		assert.Equal(t, invalid, safeParams["root"], invalid)
+	}
+	_, err = xray.RootFromTraceID("abc")
+	assert.Error(t, err)
+}
+
+func werrorsEqual(t *testing.T, wantErr, gotErr error) {
+	if wantErr == nil && gotErr == nil {
+		return
+	} else if wantErr == nil || gotErr == nil {
+		assert.Equal(t, wantErr, gotErr)
+		return
+	}
+
+	assert.Equal(t, wantErr.Error(), gotErr.Error(), "Error messages not equal")
+
+	safeParams1, unsafeParams1 := werror.ParamsFromError(wantErr)
+	safeParams2, unsafeParams2 := werror.ParamsFromError(gotErr)
+
+	assert.Equal(t, safeParams1, safeParams2, "SafeParams not equal")
+	assert.Equal(t, unsafeParams1, unsafeParams2, "UnsafeParams not equal")
+}
+
+func boolPtr(in bool) *bool {
+	return &in
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// NewIDGenerator returns a wtracing.IDGenerator that generates IDs that are compatible with X-Ray. TraceIDs are 128
+// bits: the upper 32 bits are the current time in Unix epoch seconds and the remaining 96 bits are random, so that
+// RootFromTraceID returns a root trace ID that X-Ray accepts. SpanIDs are random 64-bit values.
+func NewIDGenerator() wtracing.IDGenerator {
+	return idGenerator{}
+}
+
+type idGenerator struct{}
+
+func (idGenerator) TraceID() wtracing.TraceID {
+	return wtracing.TraceID(fmt.Sprintf("%08x%08x%016x", uint32(time.Now().Unix()), rand.Uint32(), rand.Uint64()))
+}
+
+func (idGenerator) SpanID(traceID wtracing.TraceID) wtracing.SpanID {
+	id := rand.Uint64()
+	for id == 0 {
+		id = rand.Uint64()
+	}
+	return wtracing.SpanID(fmt.Sprintf("%016x", id))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing/propagation/xray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDGenerator(t *testing.T) {
+	generator := xray.NewIDGenerator()
+
+	start := time.Now().Unix()
+	traceID := generator.TraceID()
+	end := time.Now().Unix()
+
+	require.Len(t, traceID, 32)
+	epoch, err := strconv.ParseUint(string(traceID[:8]), 16, 32)
+	require.NoError(t, err)
+	assert.True(t, int64(epoch) >= start && int64(epoch) <= end, "epoch %d is not between %d and %d", epoch, start, end)
+
+	root, err := xray.RootFromTraceID(traceID)
+	require.NoError(t, err)
+	roundTripped, err := xray.TraceIDFromRoot(root)
+	require.NoError(t, err)
+	assert.Equal(t, traceID, roundTripped)
+
+	assert.NotEqual(t, traceID, generator.TraceID())
+
+	spanID := generator.SpanID(traceID)
+	require.Len(t, spanID, 16)
+	_, err = strconv.ParseUint(string(spanID), 16, 64)
+	assert.NoError(t, err)
+	assert.NotEqual(t, spanID, generator.SpanID(""))
+}
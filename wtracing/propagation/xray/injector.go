@@ -0,0 +1,52 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// SpanInjector returns a SpanInjector that injects a wtracing.SpanContext in the "X-Amzn-Trace-Id" header of the
+// provided *http.Request.
+func SpanInjector(req *http.Request) wtracing.SpanInjector {
+	return HeaderSpanInjector(req.Header)
+}
+
+// HeaderSpanInjector returns a SpanInjector that injects a wtracing.SpanContext in the "X-Amzn-Trace-Id" header of the
+// provided http.Header. The header is only set if the TraceID is valid, in which case the root trace ID is derived from
+// it using RootFromTraceID. The parent is set if the SpanID is non-empty. If the provided span is in debug mode or is
+// sampled, the sampled value is set to "1"; if it is explicitly not sampled, the sampled value is set to "0";
+// otherwise, the sampled value is omitted.
+func HeaderSpanInjector(header http.Header) wtracing.SpanInjector {
+	return func(sc wtracing.SpanContext) {
+		root, err := RootFromTraceID(sc.TraceID)
+		if err != nil {
+			return
+		}
+		fields := []string{rootKey + "=" + root}
+		if sc.ID != "" {
+			fields = append(fields, parentKey+"="+string(sc.ID))
+		}
+		if sc.Debug || (sc.Sampled != nil && *sc.Sampled) {
+			fields = append(fields, sampledKey+"="+trueHeaderVal)
+		} else if sc.Sampled != nil {
+			fields = append(fields, sampledKey+"="+falseHeaderVal)
+		}
+		header.Set(traceHeader, strings.Join(fields, ";"))
+	}
+}
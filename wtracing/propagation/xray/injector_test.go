@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/propagation/xray"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpanInjector(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		sc            wtracing.SpanContext
+		wantHeaderVal string
+	}{
+		{
+			name: "full span context injection",
+			sc: wtracing.SpanContext{
+				TraceID: traceIDVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+			},
+			wantHeaderVal: "Root=" + rootVal + ";Parent=" + idHexVal + ";Sampled=1",
+		},
+		{
+			name: "unsampled span",
+			sc: wtracing.SpanContext{
+				TraceID: traceIDVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(false),
+			},
+			wantHeaderVal: "Root=" + rootVal + ";Parent=" + idHexVal + ";Sampled=0",
+		},
+		{
+			name: "debug span is sampled",
+			sc: wtracing.SpanContext{
+				TraceID: traceIDVal,
+				ID:      idHexVal,
+				Debug:   true,
+			},
+			wantHeaderVal: "Root=" + rootVal + ";Parent=" + idHexVal + ";Sampled=1",
+		},
+		{
+			name: "sampled omitted if not defined and parent omitted if no SpanID",
+			sc: wtracing.SpanContext{
+				TraceID: traceIDVal,
+			},
+			wantHeaderVal: "Root=" + rootVal,
+		},
+		{
+			name: "nothing injected for invalid TraceID",
+			sc: wtracing.SpanContext{
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "", nil)
+			require.NoError(t, err)
+			xray.SpanInjector(req)(tc.sc)
+			assert.Equal(t, tc.wantHeaderVal, req.Header.Get("X-Amzn-Trace-Id"))
+		})
+	}
+}
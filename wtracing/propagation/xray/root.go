@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"encoding/hex"
+	"strings"
+
+	werror "github.com/palantir/witchcraft-go-error"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// TraceIDFromRoot returns the 128-bit wtracing.TraceID that corresponds to the provided X-Ray root trace ID. An X-Ray
+// root trace ID has the form "1-{8 hex digit epoch}-{24 hex digits}", and the corresponding TraceID is the
+// concatenation of the epoch and the remaining digits.
+func TraceIDFromRoot(root string) (wtracing.TraceID, error) {
+	parts := strings.Split(root, "-")
+	if len(parts) != 3 || parts[0] != rootVersion || len(parts[1]) != 8 || len(parts[2]) != 24 {
+		return "", werror.Error("X-Ray root trace ID must have the form 1-xxxxxxxx-xxxxxxxxxxxxxxxxxxxxxxxx",
+			werror.SafeParam("root", root))
+	}
+	traceID := strings.ToLower(parts[1] + parts[2])
+	if !isHex(traceID) {
+		return "", werror.Error("X-Ray root trace ID is not valid hex", werror.SafeParam("root", root))
+	}
+	return wtracing.TraceID(traceID), nil
+}
+
+// RootFromTraceID returns the X-Ray root trace ID that corresponds to the provided TraceID. The first 8 hex digits of
+// a 128-bit TraceID are used as the epoch of the root trace ID. A 64-bit TraceID is treated as a 128-bit TraceID with
+// an upper 64 bits of 0, so its epoch is 0: X-Ray rejects such IDs, so 128-bit TraceIDs whose upper 32 bits are the
+// start time of the trace in Unix epoch seconds should be used when traces are sent to X-Ray.
+func RootFromTraceID(traceID wtracing.TraceID) (string, error) {
+	b, err := wtracing.TraceIDBytes(traceID)
+	if err != nil {
+		return "", err
+	}
+	hexID := hex.EncodeToString(b[:])
+	return rootVersion + "-" + hexID[:8] + "-" + hexID[8:], nil
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact provides a wtracing.Reporter that applies redaction and filtering rules to spans before they are
+// forwarded to another reporter. Wrapping the reporter of a tracer provides a central point for ensuring that sensitive
+// information such as credentials or personal information recorded in span tags is not written to trace logs or sent to
+// remote collectors.
+package redact
+
+import (
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// NewReporter returns a reporter that applies the provided rules in order to every span sent to it and forwards the
+// resulting span to the provided reporter. If any rule drops a span, the span is not forwarded and the remaining rules
+// are not applied. Closing the returned reporter closes the provided reporter.
+//
+// The tags and annotations of a span are copied before the rules are applied, so rules may modify them without
+// affecting the SpanModel provided by the caller.
+func NewReporter(reporter wtracing.Reporter, rules ...Rule) wtracing.Reporter {
+	return &redactingReporter{
+		reporter: reporter,
+		rules:    rules,
+	}
+}
+
+type redactingReporter struct {
+	reporter wtracing.Reporter
+	rules    []Rule
+}
+
+func (r *redactingReporter) Send(span wtracing.SpanModel) {
+	span = copySpanModel(span)
+	for _, rule := range r.rules {
+		if rule == nil {
+			continue
+		}
+		if !rule(&span) {
+			return
+		}
+	}
+	r.reporter.Send(span)
+}
+
+func (r *redactingReporter) Close() error {
+	return r.reporter.Close()
+}
+
+func copySpanModel(span wtracing.SpanModel) wtracing.SpanModel {
+	if span.Tags != nil {
+		tags := make(map[string]string, len(span.Tags))
+		for k, v := range span.Tags {
+			tags[k] = v
+		}
+		span.Tags = tags
+	}
+	if span.Annotations != nil {
+		span.Annotations = append([]wtracing.Annotation(nil), span.Annotations...)
+	}
+	return span
+}
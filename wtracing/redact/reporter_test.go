@@ -0,0 +1,182 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact_test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/redact"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		rules     []redact.Rule
+		span      wtracing.SpanModel
+		wantSpans []wtracing.SpanModel
+	}{
+		{
+			name: "no rules forwards span unmodified",
+			span: wtracing.SpanModel{
+				Name: "GET /api",
+				Tags: map[string]string{"http.url": "/api?token=secret"},
+			},
+			wantSpans: []wtracing.SpanModel{{
+				Name: "GET /api",
+				Tags: map[string]string{"http.url": "/api?token=secret"},
+			}},
+		},
+		{
+			name:  "drop span by name",
+			rules: []redact.Rule{redact.DropSpans(regexp.MustCompile(`^health`))},
+			span: wtracing.SpanModel{
+				Name: "healthcheck",
+			},
+		},
+		{
+			name: "delete and hash tags",
+			rules: []redact.Rule{
+				redact.DeleteTags(regexp.MustCompile(`^http\.request\.header\.`)),
+				redact.HashTags([]byte("secret-key"), regexp.MustCompile(`^user\.id$`)),
+			},
+			span: wtracing.SpanModel{
+				Name: "GET /api",
+				Tags: map[string]string{
+					"http.request.header.authorization": "Bearer abc",
+					"http.request.header.cookie":        "session=abc",
+					"user.id":                           "alice",
+					"http.method":                       "GET",
+				},
+			},
+			wantSpans: []wtracing.SpanModel{{
+				Name: "GET /api",
+				Tags: map[string]string{
+					"user.id":     "5c7dff8695e1bf7eab3bf03a9c7950b11f00f74f0712340fb6a84885091392ab",
+					"http.method": "GET",
+				},
+			}},
+		},
+		{
+			name: "scrub values in tags and annotations",
+			rules: []redact.Rule{
+				redact.ScrubValues(redact.EmailPattern, "<email>"),
+				redact.ScrubValues(redact.BearerTokenPattern, "Bearer <redacted>"),
+				redact.ScrubValues(redact.URLQueryPattern, ""),
+			},
+			span: wtracing.SpanModel{
+				Name: "GET /api",
+				Annotations: []wtracing.Annotation{
+					{Timestamp: time.Unix(1, 0), Value: "sent mail to alice@example.com"},
+				},
+				Tags: map[string]string{
+					"http.url":  "https://example.com/api?email=bob@example.com&key=abc#section",
+					"auth":      "bearer eyJhbGciOi.eyJzdWIiOi.SflKxwRJ==",
+					"user.name": "alice",
+				},
+			},
+			wantSpans: []wtracing.SpanModel{{
+				Name: "GET /api",
+				Annotations: []wtracing.Annotation{
+					{Timestamp: time.Unix(1, 0), Value: "sent mail to <email>"},
+				},
+				Tags: map[string]string{
+					"http.url":  "https://example.com/api#section",
+					"auth":      "Bearer <redacted>",
+					"user.name": "alice",
+				},
+			}},
+		},
+		{
+			name: "scrub names",
+			rules: []redact.Rule{
+				redact.ScrubNames(redact.EmailPattern, "<email>"),
+				redact.ScrubNames(redact.URLQueryPattern, ""),
+			},
+			span: wtracing.SpanModel{
+				Name: "GET /users/alice@example.com?token=secret",
+				Tags: map[string]string{"user.email": "alice@example.com"},
+			},
+			wantSpans: []wtracing.SpanModel{{
+				Name: "GET /users/<email>",
+				Tags: map[string]string{"user.email": "alice@example.com"},
+			}},
+		},
+		{
+			name: "custom rule",
+			rules: []redact.Rule{
+				func(span *wtracing.SpanModel) bool {
+					span.Name = "redacted"
+					return true
+				},
+			},
+			span: wtracing.SpanModel{
+				Name: "GET /users/alice",
+			},
+			wantSpans: []wtracing.SpanModel{{
+				Name: "redacted",
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recordingReporter := wtracingtest.NewRecordingReporter()
+			redact.NewReporter(recordingReporter, tc.rules...).Send(tc.span)
+			assert.Equal(t, tc.wantSpans, recordingReporter.Spans())
+		})
+	}
+}
+
+func TestHashTags(t *testing.T) {
+	hash := func(key string) string {
+		span := wtracing.SpanModel{Tags: map[string]string{"user.id": "alice"}}
+		redact.HashTags([]byte(key), regexp.MustCompile(`^user\.id$`))(&span)
+		return span.Tags["user.id"]
+	}
+	// hashes are deterministic for a given key and differ between keys
+	assert.Equal(t, hash("key"), hash("key"))
+	assert.NotEqual(t, hash("key"), hash("other-key"))
+
+	assert.Panics(t, func() {
+		redact.HashTags(nil, regexp.MustCompile(`^user\.id$`))
+	})
+}
+
+func TestReporterDoesNotModifyInput(t *testing.T) {
+	recordingReporter := wtracingtest.NewRecordingReporter()
+	reporter := redact.NewReporter(recordingReporter,
+		redact.DeleteTags(regexp.MustCompile(`^secret$`)),
+		redact.ScrubValues(redact.EmailPattern, "<email>"),
+	)
+
+	span := wtracing.SpanModel{
+		Name:        "span",
+		Annotations: []wtracing.Annotation{{Value: "alice@example.com"}},
+		Tags:        map[string]string{"secret": "value"},
+	}
+	reporter.Send(span)
+
+	assert.Equal(t, map[string]string{"secret": "value"}, span.Tags)
+	assert.Equal(t, "alice@example.com", span.Annotations[0].Value)
+
+	spans := recordingReporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, map[string]string{}, spans[0].Tags)
+	assert.Equal(t, "<email>", spans[0].Annotations[0].Value)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+var (
+	// EmailPattern matches email addresses.
+	EmailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	// BearerTokenPattern matches bearer tokens in the format used by the Authorization HTTP header.
+	BearerTokenPattern = regexp.MustCompile(`(?i)bearer\s+[a-zA-Z0-9\-._~+/]+=*`)
+	// URLQueryPattern matches the query string of a URL, including the leading '?'. Matches are scrubbed up to (but
+	// not including) any fragment.
+	URLQueryPattern = regexp.MustCompile(`\?[^#\s]*`)
+)
+
+// Rule is applied to a span by the reporter returned by NewReporter. A rule may modify the provided span and returns
+// false if the span should be dropped.
+type Rule func(span *wtracing.SpanModel) bool
+
+// DropSpans returns a rule that drops spans whose name matches the provided pattern.
+func DropSpans(namePattern *regexp.Regexp) Rule {
+	return func(span *wtracing.SpanModel) bool {
+		return !namePattern.MatchString(span.Name)
+	}
+}
+
+// DeleteTags returns a rule that deletes the tags whose keys match the provided pattern.
+func DeleteTags(keyPattern *regexp.Regexp) Rule {
+	return func(span *wtracing.SpanModel) bool {
+		for k := range span.Tags {
+			if keyPattern.MatchString(k) {
+				delete(span.Tags, k)
+			}
+		}
+		return true
+	}
+}
+
+// HashTags returns a rule that replaces the values of the tags whose keys match the provided pattern with the
+// hex-encoded HMAC-SHA256 of the value computed using the provided secret key. This removes the original value while
+// still allowing spans with the same value to be correlated.
+//
+// The output is pseudonymous rather than anonymous: anyone who knows the key can recompute the hash of a candidate
+// value (such as every known email address or user ID) and match it against the hashed tags, so the key must be kept
+// secret and should be long and random. Panics if the key is empty.
+func HashTags(key []byte, keyPattern *regexp.Regexp) Rule {
+	if len(key) == 0 {
+		panic("redact: HashTags requires a non-empty key")
+	}
+	key = append([]byte(nil), key...)
+	return func(span *wtracing.SpanModel) bool {
+		for k, v := range span.Tags {
+			if keyPattern.MatchString(k) {
+				span.Tags[k] = hashValue(key, v)
+			}
+		}
+		return true
+	}
+}
+
+// ScrubValues returns a rule that replaces all of the matches of the provided pattern in tag values and annotation
+// values with the provided replacement. The replacement is interpreted in the same manner as the replacement for
+// regexp.Regexp.ReplaceAllString, so "$1" can be used to retain the first submatch. Span names are not modified: use
+// ScrubNames to scrub names that may contain sensitive values (such as names that include a request path).
+func ScrubValues(valuePattern *regexp.Regexp, replacement string) Rule {
+	return func(span *wtracing.SpanModel) bool {
+		for k, v := range span.Tags {
+			span.Tags[k] = valuePattern.ReplaceAllString(v, replacement)
+		}
+		for i := range span.Annotations {
+			span.Annotations[i].Value = valuePattern.ReplaceAllString(span.Annotations[i].Value, replacement)
+		}
+		return true
+	}
+}
+
+// ScrubNames returns a rule that replaces all of the matches of the provided pattern in span names with the provided
+// replacement, which is interpreted in the same manner as the replacement for ScrubValues.
+func ScrubNames(namePattern *regexp.Regexp, replacement string) Rule {
+	return func(span *wtracing.SpanModel) bool {
+		span.Name = namePattern.ReplaceAllString(span.Name, replacement)
+		return true
+	}
+}
+
+func hashValue(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -34,6 +34,19 @@ type Span interface {
 	Finish()
 }
 
+// SpanAnnotator is implemented by spans that support recording timestamped annotations. Annotations are included in
+// the Annotations field of the SpanModel that is reported when the span is finished.
+type SpanAnnotator interface {
+	Annotate(timestamp time.Time, value string)
+}
+
+// SpanFinisher is implemented by spans that support being finished at a time other than the current time, such as
+// spans whose timing is recorded after the fact. The duration of the span is the difference between the provided time
+// and the start time of the span.
+type SpanFinisher interface {
+	FinishAt(finishTime time.Time)
+}
+
 type SpanModel struct {
 	SpanContext
 
@@ -43,7 +56,17 @@ type SpanModel struct {
 	Duration       time.Duration
 	LocalEndpoint  *Endpoint
 	RemoteEndpoint *Endpoint
+	Annotations    []Annotation
 	Tags           map[string]string
+	// Shared is true if the span shares its span ID with the remote client span that is its parent (see
+	// WithSharedSpans).
+	Shared bool
+}
+
+// Annotation associates an event that explains latency with a timestamp.
+type Annotation struct {
+	Timestamp time.Time
+	Value     string
 }
 
 type SpanContext struct {
@@ -53,6 +76,9 @@ type SpanContext struct {
 	Debug    bool
 	Sampled  *bool
 	Err      error
+	// Remote is true if the SpanContext was propagated from another process (for example, if it was returned by a
+	// SpanExtractor). The contexts of spans created in the current process are never remote.
+	Remote bool
 }
 
 func FromSpanOptions(opts ...SpanOption) *SpanOptionImpl {
@@ -81,6 +107,8 @@ type SpanOptionImpl struct {
 	ParentSpan     *SpanContext
 	Kind           Kind
 	Tags           map[string]string
+	// StartTime is the time at which the span starts. If zero, the span starts at the time it is created.
+	StartTime time.Time
 }
 
 func WithKind(kind Kind) SpanOption {
@@ -103,7 +131,9 @@ func WithParent(parent Span) SpanOption {
 // (TraceID and SpanID are set), the new span will use the same TraceID and set its ParentID to be the SpanID. If the
 // TraceID is set but the SpanID is not, the new span will be a root span and its TraceId and SpanID will both be the
 // same value as the TraceID in the provided context. The debug and sampled values are always inherited (regardless of
-// the other fields).
+// the other fields). If the Remote field of the provided context is true, the parent is treated as a span in another
+// process: tracers that use the shared span model (see WithSharedSpans) share the span ID of the parent for server
+// spans.
 func WithParentSpanContext(parentCtx SpanContext) SpanOption {
 	return spanOptionFn(func(impl *SpanOptionImpl) {
 		impl.ParentSpan = &parentCtx
@@ -116,6 +146,13 @@ func WithRemoteEndpoint(endpoint *Endpoint) SpanOption {
 	})
 }
 
+// WithStartTime sets the start time of the span to the provided time rather than the time at which it is created.
+func WithStartTime(startTime time.Time) SpanOption {
+	return spanOptionFn(func(impl *SpanOptionImpl) {
+		impl.StartTime = startTime
+	})
+}
+
 // WithSpanTag adds the tag indexed by name with the value specified to the set of tags defined for this span.
 // If the same name is seen multiple times the most recent will prevail.
 func WithSpanTag(name, value string) SpanOption {
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	durationMetricName = "wtracing_span_duration_seconds"
+	errorsMetricName   = "wtracing_span_errors_total"
+
+	prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// NewHandler returns an http.Handler that writes the metrics of the provided Metrics in the Prometheus text exposition
+// format. The duration of spans is exposed as the histogram "wtracing_span_duration_seconds" and the number of spans
+// with errors is exposed as the counter "wtracing_span_errors_total". Both metrics have the labels "name" and "kind".
+func NewHandler(metrics *Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", prometheusContentType)
+		bw := bufio.NewWriter(w)
+		writePrometheusText(bw, metrics.Snapshot())
+		_ = bw.Flush()
+	})
+}
+
+func writePrometheusText(w *bufio.Writer, metrics []SpanMetric) {
+	_, _ = fmt.Fprintf(w, "# HELP %s Duration of finished spans.\n", durationMetricName)
+	_, _ = fmt.Fprintf(w, "# TYPE %s histogram\n", durationMetricName)
+	for _, metric := range metrics {
+		labels := fmt.Sprintf(`name="%s",kind="%s"`, escapeLabelValue(metric.Name), escapeLabelValue(string(metric.Kind)))
+		for _, bucket := range metric.Buckets {
+			_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", durationMetricName, labels, formatSeconds(bucket.UpperBound), bucket.Count)
+		}
+		_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", durationMetricName, labels, metric.Count)
+		_, _ = fmt.Fprintf(w, "%s_sum{%s} %s\n", durationMetricName, labels, formatSeconds(metric.Sum))
+		_, _ = fmt.Fprintf(w, "%s_count{%s} %d\n", durationMetricName, labels, metric.Count)
+	}
+
+	_, _ = fmt.Fprintf(w, "# HELP %s Number of finished spans with an error tag.\n", errorsMetricName)
+	_, _ = fmt.Fprintf(w, "# TYPE %s counter\n", errorsMetricName)
+	for _, metric := range metrics {
+		labels := fmt.Sprintf(`name="%s",kind="%s"`, escapeLabelValue(metric.Name), escapeLabelValue(string(metric.Kind)))
+		_, _ = fmt.Fprintf(w, "%s{%s} %d\n", errorsMetricName, labels, metric.ErrorCount)
+	}
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabelValue escapes the provided value for use as a label value in the Prometheus text exposition format.
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spanmetrics aggregates finished spans into per-name and per-kind rate, error and duration (RED) metrics. The
+// metrics can be read using a pull API or exposed in the Prometheus text exposition format using NewHandler.
+//
+// Metrics is a wtracing.SpanProcessor: registering it on a tracer using wtracing.WithSpanProcessor records every span,
+// including spans that are not sampled. Alternatively, NewReporter returns a reporter that records the spans sent to it
+// (which only includes sampled spans) before forwarding them to another reporter.
+package spanmetrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// DefaultBuckets are the upper bounds of the duration histogram buckets used if WithBuckets is not specified.
+var DefaultBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+const defaultMaxSpanNames = 1000
+
+// OverflowSpanName is the name under which spans are recorded once Metrics has recorded spans with its maximum number
+// of distinct names (see WithMaxSpanNames).
+const OverflowSpanName = "<other>"
+
+// Option configures Metrics.
+type Option func(m *Metrics)
+
+// WithBuckets sets the upper bounds of the duration histogram buckets. The provided bounds are sorted, duplicate bounds
+// are removed and bounds less than or equal to 0 are ignored. A bucket with an infinite upper bound is always included
+// implicitly.
+func WithBuckets(buckets []time.Duration) Option {
+	return func(m *Metrics) {
+		var sorted []time.Duration
+		for _, bound := range buckets {
+			if bound > 0 {
+				sorted = append(sorted, bound)
+			}
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] < sorted[j]
+		})
+		m.buckets = nil
+		for i, bound := range sorted {
+			if i == 0 || bound != sorted[i-1] {
+				m.buckets = append(m.buckets, bound)
+			}
+		}
+	}
+}
+
+// WithMaxSpanNames sets the maximum number of distinct span names that are recorded. Once this number is reached, spans
+// with new names are recorded under OverflowSpanName so that the number of series (and the cardinality of the exported
+// metrics) is bounded even if span names are unbounded (for example, if they include request paths). Defaults to 1000.
+func WithMaxSpanNames(n int) Option {
+	return func(m *Metrics) {
+		m.maxSpanNames = n
+	}
+}
+
+// SpanMetric contains the metrics for the finished spans with a given name and kind.
+type SpanMetric struct {
+	Name string
+	Kind wtracing.Kind
+	// Count is the number of finished spans.
+	Count uint64
+	// ErrorCount is the number of finished spans that had an error tag.
+	ErrorCount uint64
+	// Sum is the total duration of all of the finished spans.
+	Sum time.Duration
+	// Buckets contains the cumulative number of finished spans with a duration less than or equal to the upper bound
+	// of each bucket. The count for the implicit bucket with an infinite upper bound is Count.
+	Buckets []Bucket
+}
+
+// Bucket is a bucket of a duration histogram.
+type Bucket struct {
+	UpperBound time.Duration
+	Count      uint64
+}
+
+// Metrics aggregates finished spans into metrics. It is safe for concurrent use.
+type Metrics struct {
+	buckets      []time.Duration
+	maxSpanNames int
+
+	mutex  sync.Mutex
+	series map[seriesKey]*series
+	// names contains the distinct span names that have been recorded (other than OverflowSpanName)
+	names map[string]struct{}
+}
+
+type seriesKey struct {
+	name string
+	kind wtracing.Kind
+}
+
+type series struct {
+	count      uint64
+	errorCount uint64
+	sum        time.Duration
+	// bucketCounts contains the non-cumulative count of each bucket
+	bucketCounts []uint64
+}
+
+// New returns a new Metrics with no recorded spans.
+func New(opts ...Option) *Metrics {
+	m := &Metrics{
+		buckets:      DefaultBuckets,
+		maxSpanNames: defaultMaxSpanNames,
+		series:       make(map[seriesKey]*series),
+		names:        make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// OnStart does nothing: spans are recorded when they are finished.
+func (m *Metrics) OnStart(wtracing.SpanContext, string, *wtracing.SpanOptionImpl) {}
+
+// OnEnd records the provided span.
+func (m *Metrics) OnEnd(span wtracing.SpanModel) {
+	m.Record(span)
+}
+
+// Record records the provided finished span.
+func (m *Metrics) Record(span wtracing.SpanModel) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := seriesKey{name: m.seriesName(span.Name), kind: span.Kind}
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{
+			bucketCounts: make([]uint64, len(m.buckets)),
+		}
+		m.series[key] = s
+	}
+
+	s.count++
+	s.sum += span.Duration
+	if _, ok := span.Tags[wtracing.ErrorTagKey]; ok {
+		s.errorCount++
+	}
+	// index of the first bucket whose upper bound is greater than or equal to the duration
+	if i := sort.Search(len(m.buckets), func(i int) bool {
+		return m.buckets[i] >= span.Duration
+	}); i < len(m.buckets) {
+		s.bucketCounts[i]++
+	}
+}
+
+// seriesName returns the name under which spans with the provided name are recorded, tracking the name if the maximum
+// number of names has not been reached. Must be called while holding m.mutex.
+func (m *Metrics) seriesName(name string) string {
+	if _, ok := m.names[name]; ok {
+		return name
+	}
+	if m.maxSpanNames > 0 && len(m.names) >= m.maxSpanNames {
+		return OverflowSpanName
+	}
+	m.names[name] = struct{}{}
+	return name
+}
+
+// Snapshot returns the current metrics for every name and kind of span that has been recorded, ordered by name and
+// then by kind.
+func (m *Metrics) Snapshot() []SpanMetric {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metrics := make([]SpanMetric, 0, len(m.series))
+	for key, s := range m.series {
+		buckets := make([]Bucket, len(m.buckets))
+		var cumulativeCount uint64
+		for i, upperBound := range m.buckets {
+			cumulativeCount += s.bucketCounts[i]
+			buckets[i] = Bucket{
+				UpperBound: upperBound,
+				Count:      cumulativeCount,
+			}
+		}
+		metrics = append(metrics, SpanMetric{
+			Name:       key.name,
+			Kind:       key.kind,
+			Count:      s.count,
+			ErrorCount: s.errorCount,
+			Sum:        s.sum,
+			Buckets:    buckets,
+		})
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Name != metrics[j].Name {
+			return metrics[i].Name < metrics[j].Name
+		}
+		return metrics[i].Kind < metrics[j].Kind
+	})
+	return metrics
+}
+
+// NewReporter returns a reporter that records every span sent to it in the provided Metrics and then forwards it to
+// the provided reporter. Closing the returned reporter closes the provided reporter.
+func NewReporter(metrics *Metrics, reporter wtracing.Reporter) wtracing.Reporter {
+	return &metricsReporter{
+		metrics:  metrics,
+		reporter: reporter,
+	}
+}
+
+type metricsReporter struct {
+	metrics  *Metrics
+	reporter wtracing.Reporter
+}
+
+func (r *metricsReporter) Send(span wtracing.SpanModel) {
+	r.metrics.Record(span)
+	r.reporter.Send(span)
+}
+
+func (r *metricsReporter) Close() error {
+	return r.reporter.Close()
+}
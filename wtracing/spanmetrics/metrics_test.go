@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/spanmetrics"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := spanmetrics.New(spanmetrics.WithBuckets([]time.Duration{time.Second, 100 * time.Millisecond}))
+	for _, span := range []wtracing.SpanModel{
+		{Name: "GET /api", Kind: wtracing.Server, Duration: 50 * time.Millisecond},
+		{Name: "GET /api", Kind: wtracing.Server, Duration: 100 * time.Millisecond},
+		{Name: "GET /api", Kind: wtracing.Server, Duration: 500 * time.Millisecond, Tags: map[string]string{wtracing.ErrorTagKey: "500"}},
+		{Name: "GET /api", Kind: wtracing.Server, Duration: 2 * time.Second},
+		{Name: "GET /api", Kind: wtracing.Client, Duration: 10 * time.Millisecond},
+		{Name: "db.query", Duration: 10 * time.Millisecond},
+	} {
+		metrics.Record(span)
+	}
+
+	assert.Equal(t, []spanmetrics.SpanMetric{
+		{
+			Name:    "GET /api",
+			Kind:    wtracing.Client,
+			Count:   1,
+			Sum:     10 * time.Millisecond,
+			Buckets: []spanmetrics.Bucket{{UpperBound: 100 * time.Millisecond, Count: 1}, {UpperBound: time.Second, Count: 1}},
+		},
+		{
+			Name:       "GET /api",
+			Kind:       wtracing.Server,
+			Count:      4,
+			ErrorCount: 1,
+			Sum:        2650 * time.Millisecond,
+			Buckets:    []spanmetrics.Bucket{{UpperBound: 100 * time.Millisecond, Count: 2}, {UpperBound: time.Second, Count: 3}},
+		},
+		{
+			Name:    "db.query",
+			Kind:    wtracing.Undetermined,
+			Count:   1,
+			Sum:     10 * time.Millisecond,
+			Buckets: []spanmetrics.Bucket{{UpperBound: 100 * time.Millisecond, Count: 1}, {UpperBound: time.Second, Count: 1}},
+		},
+	}, metrics.Snapshot())
+}
+
+func TestMetricsBuckets(t *testing.T) {
+	metrics := spanmetrics.New(spanmetrics.WithBuckets([]time.Duration{
+		time.Second, 0, 100 * time.Millisecond, -time.Second, time.Second, 100 * time.Millisecond,
+	}))
+	metrics.Record(wtracing.SpanModel{Name: "span", Duration: 0})
+	metrics.Record(wtracing.SpanModel{Name: "span", Duration: 500 * time.Millisecond})
+
+	snapshot := metrics.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, []spanmetrics.Bucket{
+		{UpperBound: 100 * time.Millisecond, Count: 1},
+		{UpperBound: time.Second, Count: 2},
+	}, snapshot[0].Buckets)
+}
+
+func TestMetricsMaxSpanNames(t *testing.T) {
+	metrics := spanmetrics.New(spanmetrics.WithMaxSpanNames(2))
+	for _, span := range []wtracing.SpanModel{
+		{Name: "a", Kind: wtracing.Server},
+		{Name: "b", Kind: wtracing.Server},
+		{Name: "c", Kind: wtracing.Server},
+		{Name: "d", Kind: wtracing.Client},
+		{Name: "a", Kind: wtracing.Client},
+	} {
+		metrics.Record(span)
+	}
+
+	var got []string
+	for _, metric := range metrics.Snapshot() {
+		got = append(got, fmt.Sprintf("%s/%s=%d", metric.Name, metric.Kind, metric.Count))
+	}
+	assert.Equal(t, []string{
+		"<other>/CLIENT=1",
+		"<other>/SERVER=1",
+		"a/CLIENT=1",
+		"a/SERVER=1",
+		"b/SERVER=1",
+	}, got)
+}
+
+func TestMetricsRecordsUnsampledSpans(t *testing.T) {
+	metrics := spanmetrics.New()
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter,
+		wtracing.WithSampler(func(id uint64) bool { return false }),
+		wtracing.WithSpanProcessor(metrics),
+	)
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("unsampled")
+	span.Tag(wtracing.ErrorTagKey, "failed")
+	span.Finish()
+
+	assert.Empty(t, reporter.Spans())
+	snapshot := metrics.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, "unsampled", snapshot[0].Name)
+	assert.Equal(t, uint64(1), snapshot[0].Count)
+	assert.Equal(t, uint64(1), snapshot[0].ErrorCount)
+}
+
+func TestReporter(t *testing.T) {
+	metrics := spanmetrics.New()
+	recordingReporter := wtracingtest.NewRecordingReporter()
+	reporter := spanmetrics.NewReporter(metrics, recordingReporter)
+
+	reporter.Send(wtracing.SpanModel{Name: "span"})
+	assert.Len(t, recordingReporter.Spans(), 1)
+	snapshot := metrics.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, uint64(1), snapshot[0].Count)
+}
+
+func TestHandler(t *testing.T) {
+	metrics := spanmetrics.New(spanmetrics.WithBuckets([]time.Duration{100 * time.Millisecond, time.Second}))
+	metrics.Record(wtracing.SpanModel{Name: `GET "/api"`, Kind: wtracing.Server, Duration: 250 * time.Millisecond})
+	metrics.Record(wtracing.SpanModel{Name: `GET "/api"`, Kind: wtracing.Server, Duration: 1500 * time.Millisecond, Tags: map[string]string{"error": "500"}})
+
+	server := httptest.NewServer(spanmetrics.NewHandler(metrics))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, strings.Join([]string{
+		`# HELP wtracing_span_duration_seconds Duration of finished spans.`,
+		`# TYPE wtracing_span_duration_seconds histogram`,
+		`wtracing_span_duration_seconds_bucket{name="GET \"/api\"",kind="SERVER",le="0.1"} 0`,
+		`wtracing_span_duration_seconds_bucket{name="GET \"/api\"",kind="SERVER",le="1"} 1`,
+		`wtracing_span_duration_seconds_bucket{name="GET \"/api\"",kind="SERVER",le="+Inf"} 2`,
+		`wtracing_span_duration_seconds_sum{name="GET \"/api\"",kind="SERVER"} 1.75`,
+		`wtracing_span_duration_seconds_count{name="GET \"/api\"",kind="SERVER"} 2`,
+		`# HELP wtracing_span_errors_total Number of finished spans with an error tag.`,
+		`# TYPE wtracing_span_errors_total counter`,
+		`wtracing_span_errors_total{name="GET \"/api\"",kind="SERVER"} 1`,
+		"",
+	}, "\n"), string(body))
+}
@@ -16,6 +16,7 @@ package wtracing
 
 import (
 	"net"
+	"time"
 )
 
 type Tracer interface {
@@ -44,8 +45,107 @@ func (t tracerOptionFn) apply(impl *TracerOptionImpl) {
 }
 
 type TracerOptionImpl struct {
-	Sampler       Sampler
-	LocalEndpoint *Endpoint
+	Sampler        Sampler
+	LocalEndpoint  *Endpoint
+	SpanLimits     SpanLimits
+	DefaultTags    map[string]string
+	SpanProcessors []SpanProcessor
+	// SpanMisuseHandler is called when a span is detected to have been used incorrectly. If nil, span misuse is not
+	// detected.
+	SpanMisuseHandler SpanMisuseHandler
+	// SharedSpans is true if server spans with remote parents (see SpanContext.Remote) should share the span ID of their
+	// parent.
+	SharedSpans bool
+	// IDGenerator generates the IDs of new spans. If nil, the tracer's default random IDs are used.
+	IDGenerator IDGenerator
+	// Clock provides the start and finish times of spans. If nil, the system clock is used.
+	Clock Clock
+}
+
+// Clock provides the current time to a tracer. Implementations must be safe for concurrent use.
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGenerator generates the trace and span IDs of new spans. Implementations must be safe for concurrent use and must
+// return valid lowercase hex IDs: TraceIDs must be 16 or 32 hex characters (64 or 128 bits) and SpanIDs must be 16 hex
+// characters (64 bits).
+type IDGenerator interface {
+	// TraceID returns the TraceID for a new root span.
+	TraceID() TraceID
+	// SpanID returns the SpanID for a new span. If the span is a root span, traceID is the TraceID that was returned
+	// by TraceID for the span; otherwise, traceID is empty.
+	SpanID(traceID TraceID) SpanID
+}
+
+// SpanMisuseType is the type of a SpanMisuse.
+type SpanMisuseType string
+
+const (
+	// SpanNotFinished indicates that a span was garbage collected without having been finished.
+	SpanNotFinished SpanMisuseType = "NOT_FINISHED"
+	// SpanFinishedMultipleTimes indicates that Finish was called on a span that was already finished.
+	SpanFinishedMultipleTimes SpanMisuseType = "FINISHED_MULTIPLE_TIMES"
+)
+
+// SpanMisuse describes a span that was used incorrectly.
+type SpanMisuse struct {
+	Type        SpanMisuseType
+	Name        string
+	SpanContext SpanContext
+	// CreationStack is the stack trace of the goroutine that started the span.
+	CreationStack string
+	// FinishStack is the stack trace of the goroutine that finished the span for the second time. Only set for
+	// SpanFinishedMultipleTimes.
+	FinishStack string
+}
+
+// SpanMisuseHandler handles a detected SpanMisuse.
+type SpanMisuseHandler func(misuse SpanMisuse)
+
+// SpanProcessor receives notifications when the spans of a tracer are started and finished. Span processors are
+// registered on a tracer using WithSpanProcessor and are called synchronously in the order in which they were
+// registered, so implementations should return quickly and must be safe for concurrent use.
+type SpanProcessor interface {
+	// OnStart is called when a span is started with the SpanContext of the new span, its name and the options that were
+	// used to start it (including any default tags of the tracer). options.StartTime is always set to the start time of
+	// the span. Tags that are added to or modified in options.Tags by OnStart are set on the span after all processors
+	// have been called. OnStart is called for all spans, including spans that are not sampled.
+	OnStart(sc SpanContext, name string, options *SpanOptionImpl)
+
+	// OnEnd is called when a span is finished with the model of the span. OnEnd is called once for every span for which
+	// OnStart was called (provided that the span is finished), before the span is sent to the reporter of the tracer.
+	// Spans that are not sampled are not sent to the reporter, but are still provided to OnEnd.
+	OnEnd(span SpanModel)
+}
+
+// DroppedTagsTagKey is the key of the tag that tracers set to the number of tags that were dropped from a span because
+// the span had reached its maximum number of tags. The tag is only set if at least one tag was dropped and does not
+// count towards the maximum number of tags.
+const DroppedTagsTagKey = "dropped_tags"
+
+// TruncatedTagsTagKey is the key of the tag that tracers set to the number of distinct tags on a span whose key or
+// value was truncated because it exceeded a length limit. The tag is only set if at least one tag was truncated and
+// does not count towards the maximum number of tags.
+const TruncatedTagsTagKey = "truncated_tags"
+
+// SpanLimits specifies limits on the size of the spans created by a tracer. A limit that is less than or equal to 0 is
+// not enforced. Lengths are measured in bytes, and values that exceed a length limit are truncated to the longest valid
+// UTF-8 prefix that is within the limit.
+type SpanLimits struct {
+	// MaxTags is the maximum number of distinct tags on a span. Once a span has reached this number of tags, tags with
+	// new keys are dropped (and counted in the DroppedTagsTagKey tag), while tags with existing keys still update the
+	// existing value.
+	MaxTags int
+	// MaxTagKeyLength is the maximum length of a tag key. Tags with truncated keys are counted in the
+	// TruncatedTagsTagKey tag. A tag whose truncated key is the same as the key of a different tag that is already set
+	// on the span is dropped (and counted in the DroppedTagsTagKey tag) rather than overwriting the existing tag.
+	MaxTagKeyLength int
+	// MaxTagValueLength is the maximum length of a tag value. Tags with truncated values are counted in the
+	// TruncatedTagsTagKey tag.
+	MaxTagValueLength int
+	// MaxNameLength is the maximum length of a span name.
+	MaxNameLength int
 }
 
 type Sampler func(id uint64) bool
@@ -62,6 +162,100 @@ func WithLocalEndpoint(endpoint *Endpoint) TracerOption {
 	})
 }
 
+// WithDefaultTags adds the provided tags to every span created by the tracer. Tags set on a span using WithSpanTag or
+// Span.Tag take precedence over default tags with the same key. If this option is specified multiple times, the tags
+// are merged, with the most recently specified value prevailing for a given key.
+func WithDefaultTags(tags map[string]string) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		if impl.DefaultTags == nil {
+			impl.DefaultTags = make(map[string]string, len(tags))
+		}
+		for k, v := range tags {
+			impl.DefaultTags[k] = v
+		}
+	})
+}
+
+// WithSpanProcessor registers the provided SpanProcessor on the tracer. This option may be specified multiple times to
+// register multiple processors, which are called in the order in which they were registered.
+func WithSpanProcessor(processor SpanProcessor) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		if processor == nil {
+			return
+		}
+		impl.SpanProcessors = append(impl.SpanProcessors, processor)
+	})
+}
+
+// WithSpanMisuseDetection enables a debug mode in which the tracer records the stack trace at which every span is
+// started and calls the provided handler when a span is finished more than once or is garbage collected without having
+// been finished. Detection of unfinished spans relies on finalizers, so the handler is called on an arbitrary goroutine
+// at some point after the span becomes unreachable (if at all). Recording stack traces is expensive, so this option
+// is intended for use in tests and debugging rather than in production.
+func WithSpanMisuseDetection(handler SpanMisuseHandler) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.SpanMisuseHandler = handler
+	})
+}
+
+// WithSharedSpans configures whether the tracer uses the Zipkin shared span model, in which a server span whose parent
+// is a client span in another process reuses the span ID of the client span rather than being assigned a new span ID.
+// When enabled, spans of kind Server whose parent is a remote SpanContext (one with Remote set to true, such as a
+// SpanContext returned by a SpanExtractor) that has a span ID are shared; spans whose parent was created in the current
+// process are never shared, regardless of whether the parent is set using WithParent or WithParentSpanContext.
+// Disabled by default.
+func WithSharedSpans(sharedSpans bool) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.SharedSpans = sharedSpans
+	})
+}
+
+// WithIDGenerator configures the tracer to use the provided generator to create the IDs of new spans. Spans that
+// reuse existing IDs (such as a root span started with a parent that only has a TraceID, or a shared server span) do
+// not use the generator for those IDs.
+func WithIDGenerator(generator IDGenerator) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.IDGenerator = generator
+	})
+}
+
+// WithClock configures the tracer to use the provided clock to determine the timestamp of a span when it is started
+// and its duration when it is finished. Timestamps of annotations are provided by the caller and are not affected.
+// This is primarily useful in tests, where a clock that returns fixed times makes the reported spans deterministic.
+func WithClock(clock Clock) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.Clock = clock
+	})
+}
+
+// WithMaxTagsPerSpan sets the maximum number of distinct tags on a span. See SpanLimits.MaxTags for details.
+func WithMaxTagsPerSpan(maxTags int) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.SpanLimits.MaxTags = maxTags
+	})
+}
+
+// WithMaxTagKeyLength sets the maximum length of a tag key. Longer keys are truncated.
+func WithMaxTagKeyLength(maxLength int) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.SpanLimits.MaxTagKeyLength = maxLength
+	})
+}
+
+// WithMaxTagValueLength sets the maximum length of a tag value. Longer values are truncated.
+func WithMaxTagValueLength(maxLength int) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.SpanLimits.MaxTagValueLength = maxLength
+	})
+}
+
+// WithMaxSpanNameLength sets the maximum length of a span name. Longer names are truncated.
+func WithMaxSpanNameLength(maxLength int) TracerOption {
+	return tracerOptionFn(func(impl *TracerOptionImpl) {
+		impl.SpanLimits.MaxNameLength = maxLength
+	})
+}
+
 type Endpoint struct {
 	ServiceName string
 	IPv4        net.IP
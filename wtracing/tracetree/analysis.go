@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetree
+
+import (
+	"sort"
+	"time"
+)
+
+// Analyses clamp the interval of every child span to the interval of its parent. Spans from different services are
+// timed using different clocks, so a child may appear to start before or end after its parent: clamping ensures that
+// such clock skew does not result in negative self times or critical path segments outside of the parent span.
+
+// SelfTime returns the portion of the duration of the span that is not covered by any of its children.
+func (n *Node) SelfTime() time.Duration {
+	start, end := n.Start(), n.End()
+	covered := time.Duration(0)
+	// children are ordered by start time, so overlapping intervals can be merged in a single pass
+	var mergedStart, mergedEnd time.Time
+	for _, child := range n.Children {
+		childStart, childEnd, ok := clamp(child, start, end)
+		if !ok {
+			continue
+		}
+		if mergedEnd.IsZero() || childStart.After(mergedEnd) {
+			covered += mergedEnd.Sub(mergedStart)
+			mergedStart, mergedEnd = childStart, childEnd
+		} else if childEnd.After(mergedEnd) {
+			mergedEnd = childEnd
+		}
+	}
+	covered += mergedEnd.Sub(mergedStart)
+	return n.Span.Duration - covered
+}
+
+// Segment is a portion of the critical path during which the span of Node was doing work.
+type Segment struct {
+	Node  *Node
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the duration of the segment.
+func (s Segment) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// CriticalPath returns the critical path of the subtree rooted at the node: the sequence of segments of the spans in
+// the subtree that determined the duration of the node, in chronological order. The critical path is determined by
+// working backwards from the end of the span: at every point, the child that finished last is on the critical path,
+// and the span itself is on the critical path whenever none of its children are. The durations of the returned segments
+// sum to the duration of the node.
+func (n *Node) CriticalPath() []Segment {
+	segments := criticalPath(n, n.Start(), n.End(), nil)
+	// segments are accumulated in reverse chronological order
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	return segments
+}
+
+// criticalPath appends the critical path of the provided node within the provided interval to segments in reverse
+// chronological order.
+func criticalPath(n *Node, start, end time.Time, segments []Segment) []Segment {
+	type interval struct {
+		node       *Node
+		start, end time.Time
+	}
+	children := make([]interval, 0, len(n.Children))
+	for _, child := range n.Children {
+		if childStart, childEnd, ok := clamp(child, start, end); ok {
+			children = append(children, interval{node: child, start: childStart, end: childEnd})
+		}
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].end.After(children[j].end)
+	})
+
+	cursor := end
+	for _, child := range children {
+		if child.end.After(cursor) {
+			// child overlaps a child that is already on the critical path
+			continue
+		}
+		if cursor.After(child.end) {
+			segments = append(segments, Segment{Node: n, Start: child.end, End: cursor})
+		}
+		segments = criticalPath(child.node, child.start, child.end, segments)
+		cursor = child.start
+	}
+	if cursor.After(start) {
+		segments = append(segments, Segment{Node: n, Start: start, End: cursor})
+	}
+	return segments
+}
+
+// CriticalPathByName returns the total duration of the provided critical path segments for each span name.
+func CriticalPathByName(segments []Segment) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for _, segment := range segments {
+		durations[segment.Node.Span.Name] += segment.Duration()
+	}
+	return durations
+}
+
+// FanOut summarizes the children of a span.
+type FanOut struct {
+	// Children is the number of children of the span.
+	Children int
+	// ByName is the number of children of the span with each name.
+	ByName map[string]int
+	// MaxConcurrency is the maximum number of children of the span that were in progress at the same time.
+	MaxConcurrency int
+}
+
+// FanOut returns a summary of the children of the span.
+func (n *Node) FanOut() FanOut {
+	fanOut := FanOut{
+		Children: len(n.Children),
+		ByName:   make(map[string]int),
+	}
+
+	type event struct {
+		time  time.Time
+		delta int
+	}
+	events := make([]event, 0, 2*len(n.Children))
+	for _, child := range n.Children {
+		fanOut.ByName[child.Span.Name]++
+		events = append(events, event{time: child.Start(), delta: 1}, event{time: child.End(), delta: -1})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].time.Equal(events[j].time) {
+			return events[i].time.Before(events[j].time)
+		}
+		// a child that ends at the same time as another starts is not concurrent with it
+		return events[i].delta < events[j].delta
+	})
+	concurrency := 0
+	for _, e := range events {
+		concurrency += e.delta
+		if concurrency > fanOut.MaxConcurrency {
+			fanOut.MaxConcurrency = concurrency
+		}
+	}
+	return fanOut
+}
+
+// clamp returns the interval of the provided node clamped to the provided interval. Returns false if the intervals do
+// not overlap.
+func clamp(n *Node, start, end time.Time) (time.Time, time.Time, bool) {
+	clampedStart, clampedEnd := n.Start(), n.End()
+	if clampedStart.Before(start) {
+		clampedStart = start
+	}
+	if clampedEnd.After(end) {
+		clampedEnd = end
+	}
+	return clampedStart, clampedEnd, clampedEnd.After(clampedStart)
+}
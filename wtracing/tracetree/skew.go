@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetree
+
+import (
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// AdjustClockSkew corrects the timestamps of the spans in the tree for clock skew between hosts. Clock skew is detected
+// at every point where a request crosses from one host to another: a server span that is the child of a client span
+// (including server spans that share the span ID of their client span). If such a server span is not contained within
+// its client span, the clock of the server is assumed to be skewed and the server span is moved so that it is centered
+// within the client span (or starts at the same time as the client span if it is longer than the client span). The
+// same adjustment is applied to all of the descendants of the server span on the same host: that is, until the next
+// client/server boundary, where skew is detected again.
+//
+// The timestamps of spans and their annotations are adjusted; durations are unchanged.
+func (t *Tree) AdjustClockSkew() {
+	for _, root := range t.Roots {
+		adjustClockSkew(root, 0)
+	}
+}
+
+// AdjustClockSkew returns copies of the provided spans, which must all belong to the same trace, with their timestamps
+// corrected for clock skew as described by Tree.AdjustClockSkew. The returned spans are in the same order as the
+// provided spans. Returns an error if the spans have different trace IDs.
+func AdjustClockSkew(spans []wtracing.SpanModel) ([]wtracing.SpanModel, error) {
+	tree, nodes, err := build(spans)
+	if err != nil {
+		return nil, err
+	}
+	tree.AdjustClockSkew()
+	adjusted := make([]wtracing.SpanModel, len(nodes))
+	for i, node := range nodes {
+		adjusted[i] = node.Span
+	}
+	return adjusted, nil
+}
+
+// adjustClockSkew subtracts the provided skew from the timestamps of the node and adjusts its descendants.
+func adjustClockSkew(n *Node, skew time.Duration) {
+	if skew != 0 {
+		n.Span.Timestamp = n.Span.Timestamp.Add(-skew)
+		if len(n.Span.Annotations) > 0 {
+			// copy annotations so that the slice provided when the tree was built is not modified
+			annotations := make([]wtracing.Annotation, len(n.Span.Annotations))
+			for i, annotation := range n.Span.Annotations {
+				annotation.Timestamp = annotation.Timestamp.Add(-skew)
+				annotations[i] = annotation
+			}
+			n.Span.Annotations = annotations
+		}
+	}
+	for _, child := range n.Children {
+		childSkew := skew
+		if n.Span.Kind == wtracing.Client && child.Span.Kind == wtracing.Server {
+			// the child is on a different host: its skew is independent of the skew of this host
+			childSkew = clockSkew(n, child)
+		}
+		adjustClockSkew(child, childSkew)
+	}
+	// adjusting the children may have changed their order
+	sortByStart(n.Children)
+}
+
+// clockSkew returns the amount by which the clock of the host of the provided server span is ahead of the clock of the
+// host of the provided client span, which must already have been adjusted. Returns 0 if the server span is contained
+// within the client span, in which case any skew cannot be detected.
+func clockSkew(client, server *Node) time.Duration {
+	if !server.Start().Before(client.Start()) && !server.End().After(client.End()) {
+		return 0
+	}
+	if server.Span.Duration > client.Span.Duration {
+		return server.Start().Sub(client.Start())
+	}
+	// assume that the network latency is the same in both directions
+	latency := (client.Span.Duration - server.Span.Duration) / 2
+	return server.Start().Sub(client.Start().Add(latency))
+}
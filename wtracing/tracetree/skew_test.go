@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetree_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/tracetree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startMillis(span wtracing.SpanModel) int {
+	return int(span.Timestamp.Sub(t0) / time.Millisecond)
+}
+
+func TestAdjustClockSkew(t *testing.T) {
+	server := newSpan("c", "b", "server", wtracing.Server, -50, 40)
+	server.Annotations = []wtracing.Annotation{{Timestamp: t0.Add(-45 * time.Millisecond), Value: "received"}}
+	spans := []wtracing.SpanModel{
+		newSpan("a", "", "frontend", wtracing.Server, 0, 100),
+		newSpan("b", "a", "client", wtracing.Client, 10, 80),
+		// clock of the host of the server is 80ms behind the clock of the frontend
+		server,
+		newSpan("d", "c", "server handler", wtracing.Undetermined, -48, 30),
+		newSpan("e", "d", "backend client", wtracing.Client, -45, 25),
+		// clock of the backend is not skewed relative to the frontend: span is contained within the adjusted client
+		newSpan("f", "e", "backend", wtracing.Server, 40, 10),
+	}
+
+	adjusted, err := tracetree.AdjustClockSkew(spans)
+	require.NoError(t, err)
+	require.Len(t, adjusted, len(spans))
+
+	var got []int
+	for _, span := range adjusted {
+		got = append(got, startMillis(span))
+	}
+	// the server is centered within the client and its descendants on the same host are moved by the same amount
+	assert.Equal(t, []int{0, 10, 30, 32, 35, 40}, got)
+	assert.Equal(t, t0.Add(35*time.Millisecond), adjusted[2].Annotations[0].Timestamp)
+	assert.Equal(t, 40*time.Millisecond, adjusted[2].Duration)
+
+	// provided spans are not modified
+	assert.Equal(t, -50, startMillis(spans[2]))
+	assert.Equal(t, t0.Add(-45*time.Millisecond), spans[2].Annotations[0].Timestamp)
+}
+
+func TestAdjustClockSkewSharedSpan(t *testing.T) {
+	adjusted, err := tracetree.AdjustClockSkew([]wtracing.SpanModel{
+		newSpan("a", "", "client", wtracing.Client, 0, 100),
+		newSpan("a", "", "server", wtracing.Server, 200, 50),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, startMillis(adjusted[0]))
+	assert.Equal(t, 25, startMillis(adjusted[1]))
+}
+
+func TestAdjustClockSkewServerLongerThanClient(t *testing.T) {
+	adjusted, err := tracetree.AdjustClockSkew([]wtracing.SpanModel{
+		newSpan("a", "", "client", wtracing.Client, 0, 100),
+		newSpan("b", "a", "server", wtracing.Server, -20, 120),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, startMillis(adjusted[1]))
+}
+
+func TestTreeAdjustClockSkewNoSkew(t *testing.T) {
+	tree, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("a", "", "client", wtracing.Client, 0, 100),
+		newSpan("b", "a", "server", wtracing.Server, 10, 20),
+		// local spans are not adjusted even if they are not contained within their parent
+		newSpan("c", "b", "local", wtracing.Undetermined, 5, 40),
+	})
+	require.NoError(t, err)
+	tree.AdjustClockSkew()
+
+	var got []int
+	tree.Walk(func(node *tracetree.Node) {
+		got = append(got, startMillis(node.Span))
+	})
+	assert.Equal(t, []int{0, 10, 5}, got)
+}
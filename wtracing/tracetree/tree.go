@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracetree reconstructs the tree of spans of a trace from the SpanModels reported for it and provides analyses
+// of the tree such as the self time of each span, the critical path of the trace and fan-out summaries, as well as
+// correction of clock skew between hosts. It is intended for offline analysis of reporter output and for asserting
+// latency budgets in tests.
+package tracetree
+
+import (
+	"sort"
+	"time"
+
+	werror "github.com/palantir/witchcraft-go-error"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// Node is a span in a Tree.
+type Node struct {
+	Span wtracing.SpanModel
+	// Parent is the parent of the span. Nil if the span is a root of the tree.
+	Parent *Node
+	// Children are the children of the span, ordered by start time.
+	Children []*Node
+}
+
+// Start returns the start time of the span.
+func (n *Node) Start() time.Time {
+	return n.Span.Timestamp
+}
+
+// End returns the end time of the span.
+func (n *Node) End() time.Time {
+	return n.Span.Timestamp.Add(n.Span.Duration)
+}
+
+// Orphan returns true if the span has a parent ID but its parent was not among the spans used to build the tree.
+func (n *Node) Orphan() bool {
+	return n.Parent == nil && n.Span.ParentID != nil
+}
+
+// Walk calls the provided function for the node and all of its descendants in depth-first order, visiting every node
+// before its children.
+func (n *Node) Walk(fn func(node *Node)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// Depth returns the number of ancestors of the node.
+func (n *Node) Depth() int {
+	depth := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Tree is the tree of spans of a single trace. A trace has a single root if all of its spans were reported, but may
+// have multiple roots if spans are missing: spans whose parents are missing are orphans that are treated as roots.
+type Tree struct {
+	TraceID wtracing.TraceID
+	// Roots are the spans that do not have a parent in the tree, ordered by start time.
+	Roots []*Node
+}
+
+// Walk calls the provided function for every node in the tree in depth-first order, visiting every node before its
+// children.
+func (t *Tree) Walk(fn func(node *Node)) {
+	for _, root := range t.Roots {
+		root.Walk(fn)
+	}
+}
+
+// Find returns all of the nodes in the tree for spans with the provided name in depth-first order.
+func (t *Tree) Find(name string) []*Node {
+	var nodes []*Node
+	t.Walk(func(node *Node) {
+		if node.Span.Name == name {
+			nodes = append(nodes, node)
+		}
+	})
+	return nodes
+}
+
+// GroupByTrace groups the provided spans by trace ID, preserving the order of the spans within each trace.
+func GroupByTrace(spans []wtracing.SpanModel) map[wtracing.TraceID][]wtracing.SpanModel {
+	traces := make(map[wtracing.TraceID][]wtracing.SpanModel)
+	for _, span := range spans {
+		traces[span.TraceID] = append(traces[span.TraceID], span)
+	}
+	return traces
+}
+
+// Build returns the tree of the provided spans, which must all belong to the same trace. Returns an error if the spans
+// have different trace IDs.
+//
+// A span is a child of the span whose ID matches its parent ID. Spans whose parent is not among the provided spans
+// become roots of the tree (see Node.Orphan), as does one span of any cycle of parent IDs. Client and server spans
+// that share a span ID (as in the Zipkin shared span model) are both retained: the server span is made a child of the
+// client span, and spans whose parent ID is the shared ID are made children of the server span.
+func Build(spans []wtracing.SpanModel) (*Tree, error) {
+	tree, _, err := build(spans)
+	return tree, err
+}
+
+// build returns the tree of the provided spans along with the node of each span, in the same order as the spans.
+func build(spans []wtracing.SpanModel) (*Tree, []*Node, error) {
+	tree := &Tree{}
+	if len(spans) == 0 {
+		return tree, nil, nil
+	}
+	tree.TraceID = spans[0].TraceID
+
+	nodes := make([]*Node, len(spans))
+	// byID contains the node that children with a given parent ID are attached to
+	byID := make(map[wtracing.SpanID]*Node, len(spans))
+	// clientByID contains client nodes so that server nodes that share their ID can be attached to them
+	clientByID := make(map[wtracing.SpanID]*Node)
+	for i, span := range spans {
+		if span.TraceID != tree.TraceID {
+			return nil, nil, werror.Error("spans belong to multiple traces",
+				werror.SafeParam("traceID", string(tree.TraceID)),
+				werror.SafeParam("otherTraceID", string(span.TraceID)))
+		}
+		node := &Node{Span: span}
+		nodes[i] = node
+		if span.Kind == wtracing.Client {
+			clientByID[span.ID] = node
+		}
+		if existing, ok := byID[span.ID]; !ok || (existing.Span.Kind == wtracing.Client && span.Kind == wtracing.Server) {
+			// children of a shared span are attached to the server span
+			byID[span.ID] = node
+		}
+	}
+
+	for _, node := range nodes {
+		var parent *Node
+		if client, ok := clientByID[node.Span.ID]; ok && node.Span.Kind == wtracing.Server && client != node {
+			parent = client
+		} else if parentID := node.Span.ParentID; parentID != nil {
+			parent = byID[*parentID]
+		}
+		if parent == nil || parent == node {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	// spans that are not reachable from a root are part of or descend from a cycle of parent IDs: break each cycle by
+	// making the first span of the cycle that is reached by following the parents of an unreachable span a root
+	reachable := make(map[*Node]bool, len(nodes))
+	markReachable := func(node *Node) {
+		node.Walk(func(node *Node) {
+			reachable[node] = true
+		})
+	}
+	for _, root := range tree.Roots {
+		markReachable(root)
+	}
+	for _, node := range nodes {
+		if reachable[node] {
+			continue
+		}
+		visited := make(map[*Node]bool)
+		cycleNode := node
+		for !visited[cycleNode] {
+			visited[cycleNode] = true
+			cycleNode = cycleNode.Parent
+		}
+		cycleNode.Parent.Children = removeNode(cycleNode.Parent.Children, cycleNode)
+		cycleNode.Parent = nil
+		tree.Roots = append(tree.Roots, cycleNode)
+		markReachable(cycleNode)
+	}
+
+	sortByStart(tree.Roots)
+	for _, node := range nodes {
+		sortByStart(node.Children)
+	}
+	return tree, nodes, nil
+}
+
+func removeNode(nodes []*Node, node *Node) []*Node {
+	for i, n := range nodes {
+		if n == node {
+			return append(nodes[:i], nodes[i+1:]...)
+		}
+	}
+	return nodes
+}
+
+func sortByStart(nodes []*Node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Start().Before(nodes[j].Start())
+	})
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetree_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/tracetree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newSpan(id, parentID, name string, kind wtracing.Kind, startMillis, durationMillis int) wtracing.SpanModel {
+	span := wtracing.SpanModel{
+		SpanContext: wtracing.SpanContext{
+			TraceID: "0000000000000001",
+			ID:      wtracing.SpanID(id),
+		},
+		Name:      name,
+		Kind:      kind,
+		Timestamp: t0.Add(time.Duration(startMillis) * time.Millisecond),
+		Duration:  time.Duration(durationMillis) * time.Millisecond,
+	}
+	if parentID != "" {
+		span.ParentID = (*wtracing.SpanID)(&parentID)
+	}
+	return span
+}
+
+func names(nodes []*tracetree.Node) []string {
+	var out []string
+	for _, node := range nodes {
+		out = append(out, node.Span.Name)
+	}
+	return out
+}
+
+// testTree returns the tree:
+//
+//	a [0, 100]
+//	├── b [10, 40]
+//	├── c [20, 90]
+//	│   └── d [30, 60]
+//	└── e [95, 120] (ends after its parent due to clock skew)
+func testTree(t *testing.T) *tracetree.Tree {
+	tree, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("e", "a", "e", wtracing.Undetermined, 95, 25),
+		newSpan("d", "c", "d", wtracing.Undetermined, 30, 30),
+		newSpan("c", "a", "c", wtracing.Undetermined, 20, 70),
+		newSpan("b", "a", "b", wtracing.Undetermined, 10, 30),
+		newSpan("a", "", "a", wtracing.Undetermined, 0, 100),
+	})
+	require.NoError(t, err)
+	return tree
+}
+
+func TestBuild(t *testing.T) {
+	tree := testTree(t)
+	assert.Equal(t, wtracing.TraceID("0000000000000001"), tree.TraceID)
+	require.Len(t, tree.Roots, 1)
+	root := tree.Roots[0]
+	assert.Equal(t, "a", root.Span.Name)
+	assert.False(t, root.Orphan())
+	assert.Equal(t, []string{"b", "c", "e"}, names(root.Children))
+	assert.Equal(t, []string{"d"}, names(root.Children[1].Children))
+	assert.Equal(t, 2, root.Children[1].Children[0].Depth())
+
+	var visited []string
+	tree.Walk(func(node *tracetree.Node) {
+		visited = append(visited, node.Span.Name)
+	})
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, visited)
+	assert.Equal(t, []string{"d"}, names(tree.Find("d")))
+}
+
+func TestBuildMissingParent(t *testing.T) {
+	tree, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("a", "", "a", wtracing.Undetermined, 0, 100),
+		newSpan("c", "missing", "c", wtracing.Undetermined, 20, 10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, names(tree.Roots))
+	assert.False(t, tree.Roots[0].Orphan())
+	assert.True(t, tree.Roots[1].Orphan())
+}
+
+func TestBuildSharedSpans(t *testing.T) {
+	tree, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("a", "", "root", wtracing.Server, 0, 100),
+		newSpan("b", "a", "server", wtracing.Server, 12, 70),
+		newSpan("c", "b", "handler", wtracing.Undetermined, 20, 50),
+		newSpan("b", "a", "client", wtracing.Client, 10, 80),
+	})
+	require.NoError(t, err)
+	require.Len(t, tree.Roots, 1)
+	client := tree.Roots[0].Children
+	assert.Equal(t, []string{"client"}, names(client))
+	assert.Equal(t, []string{"server"}, names(client[0].Children))
+	assert.Equal(t, []string{"handler"}, names(client[0].Children[0].Children))
+}
+
+func TestBuildCycle(t *testing.T) {
+	tree, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("a", "b", "a", wtracing.Undetermined, 0, 100),
+		newSpan("b", "a", "b", wtracing.Undetermined, 10, 10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, names(tree.Roots))
+	assert.Equal(t, []string{"b"}, names(tree.Roots[0].Children))
+}
+
+func TestBuildCycleWithDescendant(t *testing.T) {
+	// x is a child of a, which is part of the cycle a -> b -> a: the cycle is broken without detaching x
+	tree, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("x", "a", "x", wtracing.Undetermined, 20, 10),
+		newSpan("a", "b", "a", wtracing.Undetermined, 0, 100),
+		newSpan("b", "a", "b", wtracing.Undetermined, 10, 10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, names(tree.Roots))
+	assert.Equal(t, []string{"b", "x"}, names(tree.Roots[0].Children))
+	assert.Empty(t, tree.Roots[0].Children[0].Children)
+}
+
+func TestBuildMultipleTraces(t *testing.T) {
+	otherTraceSpan := newSpan("b", "", "b", wtracing.Undetermined, 0, 10)
+	otherTraceSpan.TraceID = "0000000000000002"
+	_, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("a", "", "a", wtracing.Undetermined, 0, 10),
+		otherTraceSpan,
+	})
+	assert.EqualError(t, err, "spans belong to multiple traces")
+
+	traces := tracetree.GroupByTrace([]wtracing.SpanModel{
+		newSpan("a", "", "a", wtracing.Undetermined, 0, 10),
+		otherTraceSpan,
+	})
+	assert.Len(t, traces, 2)
+}
+
+func TestSelfTime(t *testing.T) {
+	root := testTree(t).Roots[0]
+	// children cover [10, 90] and the clamped interval [95, 100]
+	assert.Equal(t, 15*time.Millisecond, root.SelfTime())
+	assert.Equal(t, 40*time.Millisecond, root.Children[1].SelfTime())
+	assert.Equal(t, 30*time.Millisecond, root.Children[0].SelfTime())
+}
+
+func TestCriticalPath(t *testing.T) {
+	type segment struct {
+		name       string
+		start, end int
+	}
+	var got []segment
+	var total time.Duration
+	segments := testTree(t).Roots[0].CriticalPath()
+	for _, s := range segments {
+		got = append(got, segment{
+			name:  s.Node.Span.Name,
+			start: int(s.Start.Sub(t0) / time.Millisecond),
+			end:   int(s.End.Sub(t0) / time.Millisecond),
+		})
+		total += s.Duration()
+	}
+	assert.Equal(t, []segment{
+		{"a", 0, 20},
+		{"c", 20, 30},
+		{"d", 30, 60},
+		{"c", 60, 90},
+		{"a", 90, 95},
+		{"e", 95, 100},
+	}, got)
+	assert.Equal(t, 100*time.Millisecond, total)
+	assert.Equal(t, map[string]time.Duration{
+		"a": 25 * time.Millisecond,
+		"c": 40 * time.Millisecond,
+		"d": 30 * time.Millisecond,
+		"e": 5 * time.Millisecond,
+	}, tracetree.CriticalPathByName(segments))
+}
+
+func TestFanOut(t *testing.T) {
+	tree, err := tracetree.Build([]wtracing.SpanModel{
+		newSpan("a", "", "a", wtracing.Undetermined, 0, 100),
+		newSpan("b", "a", "query", wtracing.Undetermined, 0, 10),
+		newSpan("c", "a", "query", wtracing.Undetermined, 5, 10),
+		newSpan("d", "a", "query", wtracing.Undetermined, 7, 10),
+		newSpan("e", "a", "render", wtracing.Undetermined, 17, 10),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tracetree.FanOut{
+		Children:       4,
+		ByName:         map[string]int{"query": 3, "render": 1},
+		MaxConcurrency: 3,
+	}, tree.Roots[0].FanOut())
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracingtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/tracetree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SpanTree describes the expected shape of a tree of spans. The order of Children is not significant.
+type SpanTree struct {
+	Name     string
+	Children []SpanTree
+}
+
+// FindSpans returns all of the spans in the provided slice with the provided name.
+func FindSpans(spans []wtracing.SpanModel, name string) []wtracing.SpanModel {
+	var matches []wtracing.SpanModel
+	for _, span := range spans {
+		if span.Name == name {
+			matches = append(matches, span)
+		}
+	}
+	return matches
+}
+
+// RequireSpan returns the only span in the provided slice with the provided name. If there is not exactly one such
+// span, the test fails immediately.
+func RequireSpan(t require.TestingT, spans []wtracing.SpanModel, name string) wtracing.SpanModel {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	matches := FindSpans(spans, name)
+	if len(matches) != 1 {
+		require.Fail(t, fmt.Sprintf("expected exactly 1 span with name %q, found %d", name, len(matches)))
+		return wtracing.SpanModel{}
+	}
+	return matches[0]
+}
+
+// AssertParentChild asserts that the provided child span is a direct child of the provided parent span.
+func AssertParentChild(t assert.TestingT, parent, child wtracing.SpanModel) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if parent.TraceID != child.TraceID {
+		return assert.Fail(t, fmt.Sprintf("span %q has TraceID %s, but parent span %q has TraceID %s", child.Name, child.TraceID, parent.Name, parent.TraceID))
+	}
+	if child.ParentID == nil {
+		return assert.Fail(t, fmt.Sprintf("span %q has no parent, expected parent span %q (%s)", child.Name, parent.Name, parent.ID))
+	}
+	if *child.ParentID != parent.ID {
+		return assert.Fail(t, fmt.Sprintf("span %q has ParentID %s, expected parent span %q (%s)", child.Name, *child.ParentID, parent.Name, parent.ID))
+	}
+	return true
+}
+
+// AssertTags asserts that the provided span has all of the provided tags. Tags on the span that are not in the
+// provided map are ignored.
+func AssertTags(t assert.TestingT, span wtracing.SpanModel, tags map[string]string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	actual := make(map[string]string)
+	for k := range tags {
+		if v, ok := span.Tags[k]; ok {
+			actual[k] = v
+		}
+	}
+	return assert.Equal(t, tags, actual, "tags of span %q do not match", span.Name)
+}
+
+// AssertSpanTree asserts that the provided spans contain a root span that matches the provided tree. The spans of each
+// trace are assembled into a tree using tracetree.Build: a span is considered a root span if it has no parent or if its
+// parent is not among the provided spans. The subtree rooted at the matching span must match the provided tree exactly.
+//
+// Spans that use the shared span model (see wtracing.WithSharedSpans) are supported: a shared server span is a child of
+// the client span with the same span ID, and spans whose parent ID is the ID of a shared span are children of the
+// shared server span rather than of the client span.
+func AssertSpanTree(t assert.TestingT, spans []wtracing.SpanModel, tree SpanTree) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	var roots []*tracetree.Node
+	traces := tracetree.GroupByTrace(spans)
+	for _, span := range spans {
+		traceSpans, ok := traces[span.TraceID]
+		if !ok {
+			continue
+		}
+		// build each trace once, in the order in which the traces first appear
+		delete(traces, span.TraceID)
+		traceTree, err := tracetree.Build(traceSpans)
+		if err != nil {
+			return assert.Fail(t, fmt.Sprintf("failed to build tree of trace %s: %v", span.TraceID, err))
+		}
+		roots = append(roots, traceTree.Roots...)
+	}
+
+	want := tree.String()
+	var candidates []string
+	for _, root := range roots {
+		if root.Span.Name != tree.Name {
+			continue
+		}
+		got := toSpanTree(root).String()
+		if got == want {
+			return true
+		}
+		candidates = append(candidates, got)
+	}
+	if len(candidates) == 0 {
+		return assert.Fail(t, fmt.Sprintf("no root span with name %q", tree.Name))
+	}
+	return assert.Equal(t, want, candidates[0], "span tree does not match")
+}
+
+func toSpanTree(node *tracetree.Node) SpanTree {
+	tree := SpanTree{Name: node.Span.Name}
+	for _, child := range node.Children {
+		tree.Children = append(tree.Children, toSpanTree(child))
+	}
+	return tree
+}
+
+// AssertAllSpansFinished asserts that all of the spans started using the provided tracer have been finished.
+func AssertAllSpansFinished(t assert.TestingT, tracer *TrackingTracer) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	unfinished := tracer.UnfinishedSpans()
+	if len(unfinished) == 0 {
+		return true
+	}
+	var msgs []string
+	for _, span := range unfinished {
+		msgs = append(msgs, fmt.Sprintf("span %q (%s) was started but not finished. Started at:\n%s", span.Name, span.Context.ID, span.Stack))
+	}
+	return assert.Fail(t, fmt.Sprintf("%d span(s) were not finished", len(unfinished)), strings.Join(msgs, "\n"))
+}
+
+// String returns a canonical indented representation of the tree in which the children of every node are sorted.
+func (s SpanTree) String() string {
+	var sb strings.Builder
+	s.write(&sb, 0)
+	return sb.String()
+}
+
+func (s SpanTree) write(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(s.Name)
+	sb.WriteString("\n")
+
+	// sort children by their own canonical representation so that sibling order is not significant
+	childStrs := make([]string, len(s.Children))
+	for i, child := range s.Children {
+		var childSB strings.Builder
+		child.write(&childSB, depth+1)
+		childStrs[i] = childSB.String()
+	}
+	sort.Strings(childStrs)
+	for _, childStr := range childStrs {
+		sb.WriteString(childStr)
+	}
+}
+
+type tHelper interface {
+	Helper()
+}
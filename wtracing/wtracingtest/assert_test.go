@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracingtest_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingReporterConcurrent(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tracer.StartSpan(fmt.Sprintf("span-%d", i%5)).Finish()
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, reporter.Spans(), 50)
+	assert.Len(t, reporter.SpansWithName("span-0"), 10)
+
+	reporter.Reset()
+	assert.Empty(t, reporter.Spans())
+}
+
+func TestAssertions(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+
+	root := tracer.StartSpan("root", wtracing.WithSpanTag("key", "value"))
+	child0 := tracer.StartSpan("child", wtracing.WithParent(root))
+	grandchild := tracer.StartSpan("grandchild", wtracing.WithParent(child0))
+	grandchild.Finish()
+	child0.Finish()
+	child1 := tracer.StartSpan("child", wtracing.WithParent(root))
+	child1.Finish()
+	root.Finish()
+
+	spans := reporter.Spans()
+	rootModel := wtracingtest.RequireSpan(t, spans, "root")
+	grandchildModel := wtracingtest.RequireSpan(t, spans, "grandchild")
+	assert.Len(t, wtracingtest.FindSpans(spans, "child"), 2)
+
+	for _, childModel := range wtracingtest.FindSpans(spans, "child") {
+		wtracingtest.AssertParentChild(t, rootModel, childModel)
+	}
+	wtracingtest.AssertTags(t, rootModel, map[string]string{"key": "value"})
+	wtracingtest.AssertSpanTree(t, spans, wtracingtest.SpanTree{
+		Name: "root",
+		Children: []wtracingtest.SpanTree{
+			{Name: "child"},
+			{Name: "child", Children: []wtracingtest.SpanTree{{Name: "grandchild"}}},
+		},
+	})
+
+	failT := &mockT{}
+	assert.False(t, wtracingtest.AssertParentChild(failT, grandchildModel, rootModel))
+	assert.False(t, wtracingtest.AssertTags(failT, rootModel, map[string]string{"key": "other"}))
+	assert.False(t, wtracingtest.AssertSpanTree(failT, spans, wtracingtest.SpanTree{
+		Name:     "root",
+		Children: []wtracingtest.SpanTree{{Name: "child"}},
+	}))
+	assert.False(t, wtracingtest.AssertSpanTree(failT, spans, wtracingtest.SpanTree{Name: "child"}))
+}
+
+func TestAssertSpanTreeSharedSpans(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter, wtracing.WithSharedSpans(true))
+	require.NoError(t, err)
+
+	root := tracer.StartSpan("root")
+	client := tracer.StartSpan("client", wtracing.WithParent(root), wtracing.WithKind(wtracing.Client))
+	remoteCtx := client.Context()
+	remoteCtx.Remote = true
+	server := tracer.StartSpan("server", wtracing.WithParentSpanContext(remoteCtx), wtracing.WithKind(wtracing.Server))
+	require.Equal(t, client.Context().ID, server.Context().ID)
+	handler := tracer.StartSpan("handler", wtracing.WithParent(server))
+	handler.Finish()
+	server.Finish()
+	client.Finish()
+	root.Finish()
+
+	wtracingtest.AssertSpanTree(t, reporter.Spans(), wtracingtest.SpanTree{
+		Name: "root",
+		Children: []wtracingtest.SpanTree{{
+			Name: "client",
+			Children: []wtracingtest.SpanTree{{
+				Name:     "server",
+				Children: []wtracingtest.SpanTree{{Name: "handler"}},
+			}},
+		}},
+	})
+}
+
+func TestAssertAllSpansFinished(t *testing.T) {
+	zipkinTracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter())
+	require.NoError(t, err)
+	tracer := wtracingtest.NewTrackingTracer(zipkinTracer)
+
+	span0 := tracer.StartSpan("span0")
+	span1 := tracer.StartSpan("span1", wtracing.WithParent(span0))
+
+	unfinished := tracer.UnfinishedSpans()
+	require.Len(t, unfinished, 2)
+	assert.Equal(t, "span0", unfinished[0].Name)
+	assert.Equal(t, span1.Context(), unfinished[1].Context)
+	assert.False(t, wtracingtest.AssertAllSpansFinished(&mockT{}, tracer))
+
+	span1.Finish()
+	span0.Finish()
+	wtracingtest.AssertAllSpansFinished(t, tracer)
+}
+
+func TestTrackingTracerForwardsOptionalInterfaces(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	zipkinTracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+	tracer := wtracingtest.NewTrackingTracer(zipkinTracer)
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	span := tracer.StartSpan("span", wtracing.WithStartTime(start))
+	annotator, ok := span.(wtracing.SpanAnnotator)
+	require.True(t, ok)
+	annotator.Annotate(start.Add(time.Millisecond), "annotation")
+	finisher, ok := span.(wtracing.SpanFinisher)
+	require.True(t, ok)
+	finisher.FinishAt(start.Add(time.Second))
+	wtracingtest.AssertAllSpansFinished(t, tracer)
+
+	reported := wtracingtest.RequireSpan(t, reporter.Spans(), "span")
+	assert.Equal(t, []wtracing.Annotation{{Timestamp: start.Add(time.Millisecond), Value: "annotation"}}, reported.Annotations)
+	assert.Equal(t, time.Second, reported.Duration)
+}
+
+type mockT struct{}
+
+func (mockT) Errorf(format string, args ...interface{}) {}
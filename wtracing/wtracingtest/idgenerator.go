@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracingtest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// SequentialIDGenerator is a wtracing.IDGenerator that returns sequential 64-bit IDs so that the IDs of spans created
+// by a tracer are deterministic. TraceIDs and SpanIDs are drawn from separate sequences that each start at 1. It is
+// safe for concurrent use, but IDs are only deterministic if spans are started in a deterministic order.
+type SequentialIDGenerator struct {
+	mutex       sync.Mutex
+	lastTraceID uint64
+	lastSpanID  uint64
+}
+
+// NewSequentialIDGenerator returns a new SequentialIDGenerator.
+func NewSequentialIDGenerator() *SequentialIDGenerator {
+	return &SequentialIDGenerator{}
+}
+
+func (g *SequentialIDGenerator) TraceID() wtracing.TraceID {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.lastTraceID++
+	return wtracing.TraceID(fmt.Sprintf("%016x", g.lastTraceID))
+}
+
+func (g *SequentialIDGenerator) SpanID(traceID wtracing.TraceID) wtracing.SpanID {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.lastSpanID++
+	return wtracing.SpanID(fmt.Sprintf("%016x", g.lastSpanID))
+}
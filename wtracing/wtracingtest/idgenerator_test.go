@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracingtest_test
+
+import (
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequentialIDGenerator(t *testing.T) {
+	generator := wtracingtest.NewSequentialIDGenerator()
+
+	assert.Equal(t, wtracing.TraceID("0000000000000001"), generator.TraceID())
+	assert.Equal(t, wtracing.SpanID("0000000000000001"), generator.SpanID("0000000000000001"))
+	assert.Equal(t, wtracing.SpanID("0000000000000002"), generator.SpanID(""))
+	assert.Equal(t, wtracing.TraceID("0000000000000002"), generator.TraceID())
+	assert.Equal(t, wtracing.SpanID("0000000000000003"), generator.SpanID("0000000000000002"))
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracingtest
+
+import (
+	"sync"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// RecordingReporter is a wtracing.Reporter that records all of the spans that are sent to it in memory. It is safe for
+// concurrent use.
+type RecordingReporter struct {
+	mutex sync.Mutex
+	spans []wtracing.SpanModel
+}
+
+// NewRecordingReporter returns a new empty RecordingReporter.
+func NewRecordingReporter() *RecordingReporter {
+	return &RecordingReporter{}
+}
+
+// Send records the provided span.
+func (r *RecordingReporter) Send(span wtracing.SpanModel) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+// Close is a no-op: recorded spans remain available after the reporter is closed.
+func (r *RecordingReporter) Close() error {
+	return nil
+}
+
+// Spans returns a copy of all of the spans that have been recorded, in the order in which they were sent.
+func (r *RecordingReporter) Spans() []wtracing.SpanModel {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]wtracing.SpanModel(nil), r.spans...)
+}
+
+// SpansWithName returns all of the recorded spans with the provided name, in the order in which they were sent.
+func (r *RecordingReporter) SpansWithName(name string) []wtracing.SpanModel {
+	return FindSpans(r.Spans(), name)
+}
+
+// Reset discards all of the recorded spans.
+func (r *RecordingReporter) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.spans = nil
+}
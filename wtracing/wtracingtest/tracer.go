@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wtracingtest
+
+import (
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// TrackingTracer is a wtracing.Tracer that wraps another tracer and keeps track of the spans that were started using
+// it that have not yet been finished. It is safe for concurrent use.
+type TrackingTracer struct {
+	tracer wtracing.Tracer
+
+	mutex      sync.Mutex
+	nextID     uint64
+	unfinished map[uint64]UnfinishedSpan
+}
+
+// UnfinishedSpan describes a span that was started but not finished.
+type UnfinishedSpan struct {
+	Name    string
+	Context wtracing.SpanContext
+	// Stack is the stack trace of the goroutine that started the span.
+	Stack string
+}
+
+// NewTrackingTracer returns a new TrackingTracer that starts spans using the provided tracer.
+func NewTrackingTracer(tracer wtracing.Tracer) *TrackingTracer {
+	return &TrackingTracer{
+		tracer:     tracer,
+		unfinished: make(map[uint64]UnfinishedSpan),
+	}
+}
+
+func (t *TrackingTracer) StartSpan(name string, options ...wtracing.SpanOption) wtracing.Span {
+	span := t.tracer.StartSpan(name, options...)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	id := t.nextID
+	t.nextID++
+	t.unfinished[id] = UnfinishedSpan{
+		Name:    name,
+		Context: span.Context(),
+		Stack:   string(debug.Stack()),
+	}
+	return &trackingSpan{
+		Span:   span,
+		tracer: t,
+		id:     id,
+	}
+}
+
+// UnfinishedSpans returns all of the spans started by this tracer that have not been finished.
+func (t *TrackingTracer) UnfinishedSpans() []UnfinishedSpan {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	var spans []UnfinishedSpan
+	for id := uint64(0); id < t.nextID; id++ {
+		if span, ok := t.unfinished[id]; ok {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// trackingSpan wraps the spans started by a TrackingTracer. It implements wtracing.SpanAnnotator and
+// wtracing.SpanFinisher by forwarding to the wrapped span: annotations are dropped if the wrapped span does not support
+// them, and FinishAt finishes the wrapped span at the current time if the wrapped span does not support finishing at
+// a different time.
+type trackingSpan struct {
+	wtracing.Span
+	tracer *TrackingTracer
+	id     uint64
+}
+
+func (s *trackingSpan) Annotate(timestamp time.Time, value string) {
+	if annotator, ok := s.Span.(wtracing.SpanAnnotator); ok {
+		annotator.Annotate(timestamp, value)
+	}
+}
+
+func (s *trackingSpan) Finish() {
+	s.markFinished()
+	s.Span.Finish()
+}
+
+func (s *trackingSpan) FinishAt(finishTime time.Time) {
+	s.markFinished()
+	if finisher, ok := s.Span.(wtracing.SpanFinisher); ok {
+		finisher.FinishAt(finishTime)
+		return
+	}
+	s.Span.Finish()
+}
+
+func (s *trackingSpan) markFinished() {
+	s.tracer.mutex.Lock()
+	delete(s.tracer.unfinished, s.id)
+	s.tracer.mutex.Unlock()
+}
@@ -15,10 +15,16 @@
 package wtracingtests
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +32,49 @@ import (
 type ImplProvider struct {
 	Name          string
 	TracerCreator func(reporter wtracing.Reporter, opts ...wtracing.TracerOption) (wtracing.Tracer, error)
+	// Features are the optional features that the implementation supports. The tests for optional features that are
+	// not listed are skipped.
+	Features []Feature
+}
+
+// Feature is an optional feature of a tracer implementation that is configured using a wtracing.TracerOption.
+type Feature string
+
+const (
+	// FeatureDefaultTags is support for wtracing.WithDefaultTags.
+	FeatureDefaultTags Feature = "DefaultTags"
+	// FeatureSpanLimits is support for wtracing.SpanLimits (wtracing.WithMaxTagsPerSpan and related options).
+	FeatureSpanLimits Feature = "SpanLimits"
+	// FeatureSpanProcessors is support for wtracing.WithSpanProcessor.
+	FeatureSpanProcessors Feature = "SpanProcessors"
+	// FeatureSpanMisuseDetection is support for wtracing.WithSpanMisuseDetection.
+	FeatureSpanMisuseDetection Feature = "SpanMisuseDetection"
+	// FeatureSharedSpans is support for wtracing.WithSharedSpans.
+	FeatureSharedSpans Feature = "SharedSpans"
+	// FeatureIDGenerator is support for wtracing.WithIDGenerator.
+	FeatureIDGenerator Feature = "IDGenerator"
+	// FeatureClock is support for wtracing.WithClock.
+	FeatureClock Feature = "Clock"
+)
+
+func (p ImplProvider) supports(feature Feature) bool {
+	for _, f := range p.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// runFeatureTest runs the provided test for an optional feature as a subtest, skipping it if the implementation does
+// not support the feature.
+func runFeatureTest(t *testing.T, provider ImplProvider, feature Feature, test func(t *testing.T, provider ImplProvider)) {
+	t.Run(fmt.Sprintf("%s %s", provider.Name, feature), func(t *testing.T) {
+		if !provider.supports(feature) {
+			t.Skipf("%s does not support %s", provider.Name, feature)
+		}
+		test(t, provider)
+	})
 }
 
 type noopFinishSpan wtracing.SpanContext
@@ -38,19 +87,8 @@ func (s noopFinishSpan) Tag(key string, value string) {}
 
 func (s noopFinishSpan) Finish() {}
 
-type oneSpanReporter struct {
-	spanModel wtracing.SpanModel
-}
-
-func (r *oneSpanReporter) Send(spanModel wtracing.SpanModel) {
-	r.spanModel = spanModel
-}
-
-func (r *oneSpanReporter) Close() error {
-	r.spanModel = wtracing.SpanModel{}
-	return nil
-}
-
+// RunTests runs the conformance tests for the tracer implementation of the provided ImplProvider. The tests for
+// optional features are only run if the feature is listed in the Features of the provider.
 func RunTests(t *testing.T, provider ImplProvider) {
 	tracer, err := provider.TracerCreator(wtracing.NewNoopReporter())
 	require.NoError(t, err)
@@ -64,30 +102,50 @@ func RunTests(t *testing.T, provider ImplProvider) {
 	})
 
 	t.Run(fmt.Sprintf("%s Tags", provider.Name), func(t *testing.T) {
-		oneSpanReporter := oneSpanReporter{}
-		oneSpanTracer, err := provider.TracerCreator(&oneSpanReporter)
+		reporter := wtracingtest.NewRecordingReporter()
+		recordingTracer, err := provider.TracerCreator(reporter)
 		require.NoError(t, err)
 		// assert that tags passed as span options make it through to the span model
-		span0 := oneSpanTracer.StartSpan("span0", wtracing.WithSpanTag("name0", "value0"))
+		span0 := recordingTracer.StartSpan("span0", wtracing.WithSpanTag("name0", "value0"))
 		span0.Finish()
-		value0, ok0 := oneSpanReporter.spanModel.Tags["name0"]
+		value0, ok0 := wtracingtest.RequireSpan(t, reporter.Spans(), "span0").Tags["name0"]
 		assert.True(t, ok0)
 		assert.Equal(t, "value0", value0)
 		// assert that tags after creation override existing values
-		span1 := oneSpanTracer.StartSpan("span1", wtracing.WithSpanTag("name1", "value1a"))
+		span1 := recordingTracer.StartSpan("span1", wtracing.WithSpanTag("name1", "value1a"))
 		span1.Tag("name1", "value1b")
 		span1.Finish()
-		value1, ok1 := oneSpanReporter.spanModel.Tags["name1"]
+		value1, ok1 := wtracingtest.RequireSpan(t, reporter.Spans(), "span1").Tags["name1"]
 		assert.True(t, ok1)
 		assert.Equal(t, "value1b", value1)
 		// assert that error tags persist the first value
-		span2 := oneSpanTracer.StartSpan("span2", wtracing.WithSpanTag("error", "value2a"))
+		span2 := recordingTracer.StartSpan("span2", wtracing.WithSpanTag("error", "value2a"))
 		span2.Tag("error", "value2b")
 		span2.Finish()
-		value2, ok2 := oneSpanReporter.spanModel.Tags["error"]
+		value2, ok2 := wtracingtest.RequireSpan(t, reporter.Spans(), "span2").Tags["error"]
 		assert.True(t, ok2)
 		assert.Equal(t, "value2a", value2)
 	})
+
+	t.Run(fmt.Sprintf("%s Sampling", provider.Name), func(t *testing.T) {
+		testSampling(t, provider)
+	})
+
+	t.Run(fmt.Sprintf("%s SpanModel", provider.Name), func(t *testing.T) {
+		testSpanModel(t, provider)
+	})
+
+	t.Run(fmt.Sprintf("%s Finish", provider.Name), func(t *testing.T) {
+		testFinish(t, provider)
+	})
+
+	runFeatureTest(t, provider, FeatureDefaultTags, testDefaultTags)
+	runFeatureTest(t, provider, FeatureSpanLimits, testSpanLimits)
+	runFeatureTest(t, provider, FeatureSpanProcessors, testSpanProcessor)
+	runFeatureTest(t, provider, FeatureSpanMisuseDetection, testSpanMisuseDetection)
+	runFeatureTest(t, provider, FeatureSharedSpans, testSharedSpans)
+	runFeatureTest(t, provider, FeatureIDGenerator, testIDGenerator)
+	runFeatureTest(t, provider, FeatureClock, testClock)
 }
 
 func testWithParent(t *testing.T, tracer wtracing.Tracer) {
@@ -196,3 +254,663 @@ func testWithParentSpanContext(t *testing.T, tracer wtracing.Tracer) {
 		assert.Nil(t, newSpan.Context().ParentID)                    // ParentID should be nil
 	})
 }
+
+func testDefaultTags(t *testing.T, provider ImplProvider) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := provider.TracerCreator(reporter,
+		wtracing.WithDefaultTags(map[string]string{
+			"deployment": "prod",
+			"region":     "us-east-1",
+		}),
+		wtracing.WithDefaultTags(map[string]string{
+			"region": "us-west-2",
+		}),
+	)
+	require.NoError(t, err)
+
+	// default tags are added to root spans and child spans
+	rootSpan := tracer.StartSpan("rootSpan")
+	childSpan := tracer.StartSpan("childSpan", wtracing.WithParent(rootSpan), wtracing.WithSpanTag("deployment", "staging"))
+	childSpan.Tag("region", "eu-central-1")
+	childSpan.Finish()
+	rootSpan.Finish()
+
+	assert.Equal(t, map[string]string{
+		"deployment": "prod",
+		"region":     "us-west-2",
+	}, wtracingtest.RequireSpan(t, reporter.Spans(), "rootSpan").Tags)
+
+	// span tags take precedence over default tags
+	assert.Equal(t, map[string]string{
+		"deployment": "staging",
+		"region":     "eu-central-1",
+	}, wtracingtest.RequireSpan(t, reporter.Spans(), "childSpan").Tags)
+}
+
+func testSpanLimits(t *testing.T, provider ImplProvider) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := provider.TracerCreator(reporter,
+		wtracing.WithMaxTagsPerSpan(3),
+		wtracing.WithMaxTagKeyLength(4),
+		wtracing.WithMaxTagValueLength(5),
+		wtracing.WithMaxSpanNameLength(6),
+	)
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("mySpanName", wtracing.WithSpanTag("statement", "SELECT * FROM table"))
+	span.Tag("body", "abcdefgh")
+	// truncated key collides with an existing key
+	span.Tag("statistics", "value")
+	span.Tag("ok", "ok")
+	// maximum number of tags reached
+	span.Tag("new", "value")
+	// existing tags can still be updated
+	span.Tag("ok", "new")
+	span.Finish()
+
+	reportedSpan := wtracingtest.RequireSpan(t, reporter.Spans(), "mySpan")
+	assert.Equal(t, map[string]string{
+		"stat":                       "SELEC",
+		"body":                       "abcde",
+		"ok":                         "new",
+		wtracing.DroppedTagsTagKey:   "2",
+		wtracing.TruncatedTagsTagKey: "2",
+	}, reportedSpan.Tags)
+}
+
+func testSampling(t *testing.T, provider ImplProvider) {
+	const idHexVal = "6c2f558d62a7085f"
+	alwaysSample := wtracing.WithSampler(func(id uint64) bool { return true })
+	neverSample := wtracing.WithSampler(func(id uint64) bool { return false })
+
+	for _, tc := range []struct {
+		name         string
+		sampler      wtracing.TracerOption
+		parent       *wtracing.SpanContext
+		wantSampled  *bool
+		wantDebug    bool
+		wantReported bool
+	}{
+		{
+			name:         "root span sampled by sampler",
+			sampler:      alwaysSample,
+			wantSampled:  boolPtr(true),
+			wantReported: true,
+		},
+		{
+			name:         "root span not sampled by sampler",
+			sampler:      neverSample,
+			wantSampled:  boolPtr(false),
+			wantReported: false,
+		},
+		{
+			name:    "sampled parent overrides sampler",
+			sampler: neverSample,
+			parent: &wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(true),
+			},
+			wantSampled:  boolPtr(true),
+			wantReported: true,
+		},
+		{
+			name:    "unsampled parent overrides sampler",
+			sampler: alwaysSample,
+			parent: &wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Sampled: boolPtr(false),
+			},
+			wantSampled:  boolPtr(false),
+			wantReported: false,
+		},
+		{
+			name:    "parent without sampling decision uses sampler",
+			sampler: neverSample,
+			parent: &wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+			},
+			wantSampled:  boolPtr(false),
+			wantReported: false,
+		},
+		{
+			name:    "debug parent is inherited and always reported",
+			sampler: neverSample,
+			parent: &wtracing.SpanContext{
+				TraceID: idHexVal,
+				ID:      idHexVal,
+				Debug:   true,
+			},
+			wantDebug:    true,
+			wantReported: true,
+		},
+		{
+			name:    "unsampled parent with only TraceID is inherited",
+			sampler: alwaysSample,
+			parent: &wtracing.SpanContext{
+				TraceID: idHexVal,
+				Sampled: boolPtr(false),
+			},
+			wantSampled:  boolPtr(false),
+			wantReported: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := wtracingtest.NewRecordingReporter()
+			tracer, err := provider.TracerCreator(reporter, tc.sampler)
+			require.NoError(t, err)
+
+			var opts []wtracing.SpanOption
+			if tc.parent != nil {
+				opts = append(opts, wtracing.WithParentSpanContext(*tc.parent))
+			}
+			span := tracer.StartSpan("testSpan", opts...)
+			childSpan := tracer.StartSpan("childSpan", wtracing.WithParent(span))
+
+			for _, sc := range []wtracing.SpanContext{span.Context(), childSpan.Context()} {
+				assert.Equal(t, tc.wantDebug, sc.Debug)
+				if !tc.wantDebug {
+					assert.Equal(t, tc.wantSampled, sc.Sampled)
+				}
+			}
+
+			childSpan.Finish()
+			span.Finish()
+			if tc.wantReported {
+				assert.Len(t, reporter.Spans(), 2)
+			} else {
+				assert.Empty(t, reporter.Spans())
+			}
+		})
+	}
+
+	t.Run("reported span with only TraceID parent", func(t *testing.T) {
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter)
+		require.NoError(t, err)
+
+		tracer.StartSpan("testSpan", wtracing.WithParentSpanContext(wtracing.SpanContext{
+			TraceID: idHexVal,
+			Sampled: boolPtr(true),
+		})).Finish()
+
+		spanModel := wtracingtest.RequireSpan(t, reporter.Spans(), "testSpan")
+		assert.Equal(t, idHexVal, string(spanModel.TraceID))
+		assert.Equal(t, idHexVal, string(spanModel.ID))
+		assert.Nil(t, spanModel.ParentID)
+	})
+}
+
+func testSpanModel(t *testing.T, provider ImplProvider) {
+	localEndpoint := &wtracing.Endpoint{
+		ServiceName: "local-service",
+		IPv4:        net.ParseIP("10.0.0.1").To4(),
+		Port:        8443,
+	}
+	remoteEndpoint := &wtracing.Endpoint{
+		ServiceName: "remote-service",
+		IPv6:        net.ParseIP("2001:db8::1"),
+		Port:        443,
+	}
+
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := provider.TracerCreator(reporter, wtracing.WithLocalEndpoint(localEndpoint))
+	require.NoError(t, err)
+
+	for _, kind := range []wtracing.Kind{
+		wtracing.Undetermined,
+		wtracing.Client,
+		wtracing.Server,
+		wtracing.Producer,
+		wtracing.Consumer,
+	} {
+		t.Run(fmt.Sprintf("kind %q", kind), func(t *testing.T) {
+			reporter.Reset()
+
+			beforeStart := time.Now()
+			span := tracer.StartSpan("testSpan", wtracing.WithKind(kind), wtracing.WithRemoteEndpoint(remoteEndpoint))
+			afterStart := time.Now()
+			span.Finish()
+			afterFinish := time.Now()
+
+			spanModel := wtracingtest.RequireSpan(t, reporter.Spans(), "testSpan")
+			assert.Equal(t, span.Context().TraceID, spanModel.TraceID)
+			assert.Equal(t, span.Context().ID, spanModel.ID)
+			assert.Equal(t, kind, spanModel.Kind)
+			assert.Equal(t, localEndpoint, spanModel.LocalEndpoint)
+			assert.Equal(t, remoteEndpoint, spanModel.RemoteEndpoint)
+
+			// timestamp must be taken when the span is started and duration must cover the time until it is finished
+			assert.False(t, spanModel.Timestamp.Before(beforeStart), "timestamp %v before start %v", spanModel.Timestamp, beforeStart)
+			assert.False(t, spanModel.Timestamp.After(afterStart), "timestamp %v after start %v", spanModel.Timestamp, afterStart)
+			assert.True(t, spanModel.Duration >= 0, "duration %v is negative", spanModel.Duration)
+			assert.False(t, spanModel.Timestamp.Add(spanModel.Duration).After(afterFinish), "span ends after %v", afterFinish)
+		})
+	}
+}
+
+func testFinish(t *testing.T, provider ImplProvider) {
+	t.Run("double finish reports once", func(t *testing.T) {
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter)
+		require.NoError(t, err)
+
+		span := tracer.StartSpan("testSpan")
+		span.Finish()
+		span.Finish()
+		assert.Len(t, reporter.Spans(), 1)
+	})
+
+	t.Run("concurrent tag and finish", func(t *testing.T) {
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter)
+		require.NoError(t, err)
+
+		span := tracer.StartSpan("testSpan")
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				span.Tag(fmt.Sprintf("key%d", i), "value")
+			}(i)
+		}
+		wg.Wait()
+
+		// tags set concurrently with Finish may or may not be reported, but the span must be reported exactly once
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				span.Tag(fmt.Sprintf("late%d", i), "value")
+				span.Finish()
+			}(i)
+		}
+		wg.Wait()
+
+		spanModel := wtracingtest.RequireSpan(t, reporter.Spans(), "testSpan")
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, "value", spanModel.Tags[fmt.Sprintf("key%d", i)])
+		}
+	})
+}
+
+// recordingProcessor is a wtracing.SpanProcessor that records the calls made to it in a shared event log.
+type recordingProcessor struct {
+	id     string
+	mutex  *sync.Mutex
+	events *[]string
+
+	started []wtracing.SpanContext
+	ended   []wtracing.SpanModel
+}
+
+func (p *recordingProcessor) OnStart(sc wtracing.SpanContext, name string, options *wtracing.SpanOptionImpl) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	*p.events = append(*p.events, fmt.Sprintf("%s start %s", p.id, name))
+	p.started = append(p.started, sc)
+	if options.Tags == nil {
+		options.Tags = make(map[string]string)
+	}
+	options.Tags[p.id] = "started"
+}
+
+func (p *recordingProcessor) OnEnd(span wtracing.SpanModel) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	*p.events = append(*p.events, fmt.Sprintf("%s end %s", p.id, span.Name))
+	p.ended = append(p.ended, span)
+}
+
+func testSpanProcessor(t *testing.T, provider ImplProvider) {
+	newProcessors := func() (*recordingProcessor, *recordingProcessor, *[]string) {
+		var mutex sync.Mutex
+		var events []string
+		return &recordingProcessor{id: "p0", mutex: &mutex, events: &events},
+			&recordingProcessor{id: "p1", mutex: &mutex, events: &events},
+			&events
+	}
+
+	t.Run("sampled span", func(t *testing.T) {
+		p0, p1, events := newProcessors()
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter, wtracing.WithSpanProcessor(p0), wtracing.WithSpanProcessor(p1))
+		require.NoError(t, err)
+
+		span := tracer.StartSpan("testSpan", wtracing.WithSpanTag("startTag", "startValue"))
+		span.Tag("tag", "value")
+		span.Finish()
+		span.Finish()
+
+		assert.Equal(t, []string{"p0 start testSpan", "p1 start testSpan", "p0 end testSpan", "p1 end testSpan"}, *events)
+		assert.Equal(t, []wtracing.SpanContext{span.Context()}, p0.started)
+
+		// tags added by processors on start are set on the span
+		wantTags := map[string]string{
+			"startTag": "startValue",
+			"tag":      "value",
+			"p0":       "started",
+			"p1":       "started",
+		}
+		reportedSpan := wtracingtest.RequireSpan(t, reporter.Spans(), "testSpan")
+		assert.Equal(t, wantTags, reportedSpan.Tags)
+		require.Len(t, p1.ended, 1)
+		assert.Equal(t, reportedSpan, p1.ended[0])
+	})
+
+	t.Run("unsampled span", func(t *testing.T) {
+		p0, p1, events := newProcessors()
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter,
+			wtracing.WithSampler(func(id uint64) bool { return false }),
+			wtracing.WithSpanProcessor(p0),
+			wtracing.WithSpanProcessor(p1),
+		)
+		require.NoError(t, err)
+
+		span := tracer.StartSpan("testSpan", wtracing.WithKind(wtracing.Server), wtracing.WithSpanTag("startTag", "startValue"))
+		span.Tag("tag", "value")
+		span.Finish()
+		span.Finish()
+
+		// spans that are not sampled are not reported, but are still provided to processors
+		assert.Empty(t, reporter.Spans())
+		assert.Equal(t, []string{"p0 start testSpan", "p1 start testSpan", "p0 end testSpan", "p1 end testSpan"}, *events)
+		require.Len(t, p0.ended, 1)
+		ended := p0.ended[0]
+		assert.Equal(t, span.Context(), ended.SpanContext)
+		assert.Equal(t, "testSpan", ended.Name)
+		assert.Equal(t, wtracing.Server, ended.Kind)
+		assert.False(t, ended.Timestamp.IsZero())
+		assert.True(t, ended.Duration >= 0, "duration %v is negative", ended.Duration)
+		assert.Equal(t, map[string]string{
+			"startTag": "startValue",
+			"tag":      "value",
+			"p0":       "started",
+			"p1":       "started",
+		}, ended.Tags)
+	})
+}
+
+func testSharedSpans(t *testing.T, provider ImplProvider) {
+	const traceIDHexVal = "6c2f558d62a7085f"
+	const spanIDHexVal = "1a2b3c4d5e6f7081"
+	const parentIDHexVal = "0f1e2d3c4b5a6978"
+
+	parentID := wtracing.SpanID(parentIDHexVal)
+	remoteParent := wtracing.SpanContext{
+		TraceID:  traceIDHexVal,
+		ID:       spanIDHexVal,
+		ParentID: &parentID,
+		Sampled:  boolPtr(true),
+		Remote:   true,
+	}
+	// the context of a shared span has the same values as its remote parent, but is not itself remote
+	sharedCtx := remoteParent
+	sharedCtx.Remote = false
+
+	t.Run("server span with remote parent shares span ID", func(t *testing.T) {
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter, wtracing.WithSharedSpans(true))
+		require.NoError(t, err)
+
+		span := tracer.StartSpan("serverSpan", wtracing.WithKind(wtracing.Server), wtracing.WithParentSpanContext(remoteParent))
+		span.Finish()
+
+		assert.Equal(t, sharedCtx, span.Context())
+		reportedSpan := wtracingtest.RequireSpan(t, reporter.Spans(), "serverSpan")
+		assert.Equal(t, sharedCtx, reportedSpan.SpanContext)
+		assert.True(t, reportedSpan.Shared)
+	})
+
+	t.Run("server span with remote parent in context shares span ID", func(t *testing.T) {
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter, wtracing.WithSharedSpans(true))
+		require.NoError(t, err)
+
+		ctx := wtracing.ContextWithSpanContext(context.Background(), remoteParent)
+		span, _ := wtracing.StartSpanFromContext(ctx, tracer, "serverSpan", wtracing.WithKind(wtracing.Server))
+		span.Finish()
+
+		assert.Equal(t, sharedCtx, span.Context())
+		assert.True(t, wtracingtest.RequireSpan(t, reporter.Spans(), "serverSpan").Shared)
+	})
+
+	for _, tc := range []struct {
+		name       string
+		tracerOpts []wtracing.TracerOption
+		spanOpts   func(tracer wtracing.Tracer) []wtracing.SpanOption
+	}{
+		{
+			name: "shared spans not enabled",
+			spanOpts: func(tracer wtracing.Tracer) []wtracing.SpanOption {
+				return []wtracing.SpanOption{wtracing.WithKind(wtracing.Server), wtracing.WithParentSpanContext(remoteParent)}
+			},
+		},
+		{
+			name:       "client span with remote parent",
+			tracerOpts: []wtracing.TracerOption{wtracing.WithSharedSpans(true)},
+			spanOpts: func(tracer wtracing.Tracer) []wtracing.SpanOption {
+				return []wtracing.SpanOption{wtracing.WithKind(wtracing.Client), wtracing.WithParentSpanContext(remoteParent)}
+			},
+		},
+		{
+			name:       "server span with local parent",
+			tracerOpts: []wtracing.TracerOption{wtracing.WithSharedSpans(true)},
+			spanOpts: func(tracer wtracing.Tracer) []wtracing.SpanOption {
+				parent := tracer.StartSpan("parentSpan", wtracing.WithKind(wtracing.Client))
+				defer parent.Finish()
+				return []wtracing.SpanOption{wtracing.WithKind(wtracing.Server), wtracing.WithParent(parent)}
+			},
+		},
+		{
+			name:       "server span with local parent span context",
+			tracerOpts: []wtracing.TracerOption{wtracing.WithSharedSpans(true)},
+			spanOpts: func(tracer wtracing.Tracer) []wtracing.SpanOption {
+				parent := tracer.StartSpan("parentSpan", wtracing.WithKind(wtracing.Client))
+				defer parent.Finish()
+				return []wtracing.SpanOption{wtracing.WithKind(wtracing.Server), wtracing.WithParentSpanContext(parent.Context())}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := wtracingtest.NewRecordingReporter()
+			tracer, err := provider.TracerCreator(reporter, tc.tracerOpts...)
+			require.NoError(t, err)
+
+			spanOpts := tc.spanOpts(tracer)
+			parentCtx := wtracing.FromSpanOptions(spanOpts...).ParentSpan
+			span := tracer.StartSpan("childSpan", spanOpts...)
+			span.Finish()
+
+			assert.Equal(t, parentCtx.TraceID, span.Context().TraceID)
+			assert.NotEqual(t, parentCtx.ID, span.Context().ID)
+			require.NotNil(t, span.Context().ParentID)
+			assert.Equal(t, parentCtx.ID, *span.Context().ParentID)
+			assert.False(t, wtracingtest.RequireSpan(t, reporter.Spans(), "childSpan").Shared)
+		})
+	}
+
+	t.Run("server span with TraceID-only parent", func(t *testing.T) {
+		reporter := wtracingtest.NewRecordingReporter()
+		tracer, err := provider.TracerCreator(reporter, wtracing.WithSharedSpans(true))
+		require.NoError(t, err)
+
+		span := tracer.StartSpan("serverSpan", wtracing.WithKind(wtracing.Server), wtracing.WithParentSpanContext(wtracing.SpanContext{
+			TraceID: traceIDHexVal,
+			Remote:  true,
+		}))
+		span.Finish()
+
+		assert.Equal(t, wtracing.TraceID(traceIDHexVal), span.Context().TraceID)
+		assert.Equal(t, wtracing.SpanID(traceIDHexVal), span.Context().ID)
+		assert.Nil(t, span.Context().ParentID)
+		assert.False(t, wtracingtest.RequireSpan(t, reporter.Spans(), "serverSpan").Shared)
+	})
+}
+
+func testIDGenerator(t *testing.T, provider ImplProvider) {
+	tracer, err := provider.TracerCreator(wtracing.NewNoopReporter(), wtracing.WithIDGenerator(wtracingtest.NewSequentialIDGenerator()))
+	require.NoError(t, err)
+
+	// IDs of root and child spans are created by the generator
+	rootSpan := tracer.StartSpan("rootSpan")
+	childSpan := tracer.StartSpan("childSpan", wtracing.WithParent(rootSpan))
+	otherRootSpan := tracer.StartSpan("otherRootSpan")
+
+	assert.Equal(t, wtracing.TraceID("0000000000000001"), rootSpan.Context().TraceID)
+	assert.Equal(t, wtracing.SpanID("0000000000000001"), rootSpan.Context().ID)
+	assert.Nil(t, rootSpan.Context().ParentID)
+
+	assert.Equal(t, wtracing.TraceID("0000000000000001"), childSpan.Context().TraceID)
+	assert.Equal(t, wtracing.SpanID("0000000000000002"), childSpan.Context().ID)
+	require.NotNil(t, childSpan.Context().ParentID)
+	assert.Equal(t, wtracing.SpanID("0000000000000001"), *childSpan.Context().ParentID)
+
+	assert.Equal(t, wtracing.TraceID("0000000000000002"), otherRootSpan.Context().TraceID)
+	assert.Equal(t, wtracing.SpanID("0000000000000003"), otherRootSpan.Context().ID)
+
+	// root span with a TraceID-only parent uses the TraceID of the parent rather than the generator
+	const idHexVal = "6c2f558d62a7085f"
+	traceIDOnlySpan := tracer.StartSpan("traceIDOnlySpan", wtracing.WithParentSpanContext(wtracing.SpanContext{
+		TraceID: idHexVal,
+	}))
+	assert.Equal(t, wtracing.TraceID(idHexVal), traceIDOnlySpan.Context().TraceID)
+	assert.Equal(t, wtracing.SpanID(idHexVal), traceIDOnlySpan.Context().ID)
+
+	for _, span := range []wtracing.Span{rootSpan, childSpan, otherRootSpan, traceIDOnlySpan} {
+		span.Finish()
+	}
+}
+
+func testClock(t *testing.T, provider ImplProvider) {
+	start := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name    string
+		sampler wtracing.Sampler
+	}{
+		{
+			name:    "sampled span",
+			sampler: func(id uint64) bool { return true },
+		},
+		{
+			name:    "unsampled span",
+			sampler: func(id uint64) bool { return false },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sampled := tc.sampler(0)
+			hasProcessors := provider.supports(FeatureSpanProcessors)
+			if !sampled && !hasProcessors {
+				t.Skip("unsampled spans are only reported to span processors")
+			}
+
+			clock := wtracingtest.NewManualClock(start)
+			processor := &recordingProcessor{id: "p0", mutex: &sync.Mutex{}, events: &[]string{}}
+			reporter := wtracingtest.NewRecordingReporter()
+			tracerOpts := []wtracing.TracerOption{
+				wtracing.WithClock(clock),
+				wtracing.WithSampler(tc.sampler),
+			}
+			if hasProcessors {
+				tracerOpts = append(tracerOpts, wtracing.WithSpanProcessor(processor))
+			}
+			tracer, err := provider.TracerCreator(reporter, tracerOpts...)
+			require.NoError(t, err)
+
+			rootSpan := tracer.StartSpan("rootSpan")
+			clock.Advance(time.Second)
+			childSpan := tracer.StartSpan("childSpan", wtracing.WithParent(rootSpan))
+			clock.Advance(250 * time.Millisecond)
+			childSpan.Finish()
+			clock.Advance(time.Millisecond)
+			rootSpan.Finish()
+			clock.Advance(time.Second)
+			// finishing again does not change the duration
+			rootSpan.Finish()
+
+			ended := reporter.Spans()
+			if hasProcessors {
+				ended = processor.ended
+				if sampled {
+					assert.Equal(t, ended, reporter.Spans())
+				} else {
+					assert.Empty(t, reporter.Spans())
+				}
+			}
+
+			require.Len(t, ended, 2)
+			assert.Equal(t, "childSpan", ended[0].Name)
+			assert.Equal(t, start.Add(time.Second), ended[0].Timestamp)
+			assert.Equal(t, 250*time.Millisecond, ended[0].Duration)
+			assert.Equal(t, "rootSpan", ended[1].Name)
+			assert.Equal(t, start, ended[1].Timestamp)
+			assert.Equal(t, 1251*time.Millisecond, ended[1].Duration)
+		})
+	}
+}
+
+func testSpanMisuseDetection(t *testing.T, provider ImplProvider) {
+	newTracer := func(t *testing.T) (wtracing.Tracer, chan wtracing.SpanMisuse) {
+		misuses := make(chan wtracing.SpanMisuse, 10)
+		tracer, err := provider.TracerCreator(wtracing.NewNoopReporter(), wtracing.WithSpanMisuseDetection(func(misuse wtracing.SpanMisuse) {
+			misuses <- misuse
+		}))
+		require.NoError(t, err)
+		return tracer, misuses
+	}
+
+	t.Run("finished multiple times", func(t *testing.T) {
+		tracer, misuses := newTracer(t)
+
+		span := tracer.StartSpan("testSpan")
+		span.Finish()
+		assert.Empty(t, misuses)
+
+		span.Finish()
+		require.Len(t, misuses, 1)
+		misuse := <-misuses
+		assert.Equal(t, wtracing.SpanFinishedMultipleTimes, misuse.Type)
+		assert.Equal(t, "testSpan", misuse.Name)
+		assert.Equal(t, span.Context(), misuse.SpanContext)
+		assert.NotEmpty(t, misuse.CreationStack)
+		assert.NotEmpty(t, misuse.FinishStack)
+	})
+
+	t.Run("not finished", func(t *testing.T) {
+		tracer, misuses := newTracer(t)
+
+		traceID := startUnfinishedSpan(tracer)
+
+		// finalizers run after a garbage collection cycle on a separate goroutine
+		deadline := time.After(5 * time.Second)
+		for {
+			runtime.GC()
+			select {
+			case misuse := <-misuses:
+				assert.Equal(t, wtracing.SpanNotFinished, misuse.Type)
+				assert.Equal(t, "leakedSpan", misuse.Name)
+				assert.Equal(t, traceID, misuse.SpanContext.TraceID)
+				assert.NotEmpty(t, misuse.CreationStack)
+				assert.Empty(t, misuse.FinishStack)
+				return
+			case <-deadline:
+				require.Fail(t, "unfinished span was not reported")
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+}
+
+//go:noinline
+func startUnfinishedSpan(tracer wtracing.Tracer) wtracing.TraceID {
+	return tracer.StartSpan("leakedSpan").Context().TraceID
+}
+
+func boolPtr(in bool) *bool {
+	return &in
+}
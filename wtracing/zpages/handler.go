@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages
+
+import (
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+const (
+	typeParam   = "type"
+	nameParam   = "name"
+	bucketParam = "bucket"
+
+	typeActive  = "active"
+	typeLatency = "latency"
+	typeError   = "error"
+)
+
+// NewHandler returns an http.Handler that renders the contents of the provided registry as an HTML page. Without query
+// parameters, the page lists every span name with its number of active spans, the number of finished spans in each
+// latency bucket and the number of finished spans with errors. Each count links to a page that lists the corresponding
+// spans with their trace ID, span ID, start time, age or duration and tags.
+func NewHandler(registry *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		name := query.Get(nameParam)
+
+		var data interface{}
+		var tmpl *template.Template
+		switch query.Get(typeParam) {
+		case typeActive:
+			tmpl, data = spansTemplate, activeSpansPage(registry, name)
+		case typeLatency:
+			bucket, err := strconv.Atoi(query.Get(bucketParam))
+			if err != nil || bucket < 0 || bucket >= len(LatencyBucketBounds) {
+				http.Error(w, "invalid bucket", http.StatusBadRequest)
+				return
+			}
+			tmpl, data = spansTemplate, finishedSpansPage(name, bucketLabel(bucket), registry.LatencySamples(name, bucket))
+		case typeError:
+			tmpl, data = spansTemplate, finishedSpansPage(name, "errors", registry.ErrorSamples(name))
+		case "":
+			tmpl, data = summaryTemplate, summaryPage(registry)
+		default:
+			http.Error(w, "invalid type", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := tmpl.Execute(w, data); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+type summaryPageData struct {
+	BucketLabels []string
+	Summaries    []Summary
+}
+
+func summaryPage(registry *Registry) summaryPageData {
+	labels := make([]string, len(LatencyBucketBounds))
+	for i := range labels {
+		labels[i] = bucketLabel(i)
+	}
+	return summaryPageData{
+		BucketLabels: labels,
+		Summaries:    registry.Summaries(),
+	}
+}
+
+type spansPageData struct {
+	Title     string
+	TimeLabel string
+	Spans     []spanRow
+}
+
+type spanRow struct {
+	TraceID wtracing.TraceID
+	ID      wtracing.SpanID
+	Name    string
+	Kind    wtracing.Kind
+	Start   string
+	Time    time.Duration
+	Tags    []tagRow
+}
+
+type tagRow struct {
+	Key   string
+	Value string
+}
+
+func activeSpansPage(registry *Registry, name string) spansPageData {
+	now := registry.clock.Now()
+	var rows []spanRow
+	for _, activeSpan := range registry.ActiveSpans() {
+		if registry.SummaryName(activeSpan.Name) != name {
+			continue
+		}
+		rows = append(rows, spanRow{
+			TraceID: activeSpan.TraceID,
+			ID:      activeSpan.ID,
+			Name:    activeSpan.Name,
+			Kind:    activeSpan.Kind,
+			Start:   activeSpan.Start.Format(time.RFC3339Nano),
+			Time:    now.Sub(activeSpan.Start),
+			Tags:    tagRows(activeSpan.Tags),
+		})
+	}
+	return spansPageData{
+		Title:     name + ": active",
+		TimeLabel: "Age",
+		Spans:     rows,
+	}
+}
+
+func finishedSpansPage(name, label string, spans []wtracing.SpanModel) spansPageData {
+	rows := make([]spanRow, len(spans))
+	for i, span := range spans {
+		rows[i] = spanRow{
+			TraceID: span.TraceID,
+			ID:      span.ID,
+			Name:    span.Name,
+			Kind:    span.Kind,
+			Start:   span.Timestamp.Format(time.RFC3339Nano),
+			Time:    span.Duration,
+			Tags:    tagRows(span.Tags),
+		}
+	}
+	return spansPageData{
+		Title:     name + ": " + label,
+		TimeLabel: "Duration",
+		Spans:     rows,
+	}
+}
+
+func tagRows(tags map[string]string) []tagRow {
+	rows := make([]tagRow, 0, len(tags))
+	for k, v := range tags {
+		rows = append(rows, tagRow{Key: k, Value: v})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Key < rows[j].Key
+	})
+	return rows
+}
+
+func bucketLabel(bucket int) string {
+	if bucket == len(LatencyBucketBounds)-1 {
+		return ">=" + LatencyBucketBounds[bucket].String()
+	}
+	return "[" + LatencyBucketBounds[bucket].String() + ", " + LatencyBucketBounds[bucket+1].String() + ")"
+}
+
+var summaryTemplate = template.Must(template.New("summary").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Spans</title></head>
+<body>
+<h1>Spans</h1>
+<table border="1">
+<tr><th>Name</th><th>Active</th>{{range .BucketLabels}}<th>{{.}}</th>{{end}}<th>Errors</th></tr>
+{{range .Summaries}}{{$name := .Name}}<tr>
+<td>{{.Name}}</td>
+<td><a href="?type=active&amp;name={{.Name}}">{{.Active}}</a></td>
+{{range $bucket, $count := .LatencyCounts}}<td><a href="?type=latency&amp;name={{$name}}&amp;bucket={{$bucket}}">{{$count}}</a></td>{{end}}
+<td><a href="?type=error&amp;name={{.Name}}">{{.ErrorCount}}</a></td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+var spansTemplate = template.Must(template.New("spans").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p><a href="?">All spans</a></p>
+<table border="1">
+<tr><th>Trace ID</th><th>Span ID</th><th>Name</th><th>Kind</th><th>Start</th><th>{{.TimeLabel}}</th><th>Tags</th></tr>
+{{range .Spans}}<tr>
+<td>{{.TraceID}}</td>
+<td>{{.ID}}</td>
+<td>{{.Name}}</td>
+<td>{{.Kind}}</td>
+<td>{{.Start}}</td>
+<td>{{.Time}}</td>
+<td>{{range .Tags}}{{.Key}}={{.Value}}<br>{{end}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
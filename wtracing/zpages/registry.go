@@ -0,0 +1,348 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zpages provides a registry that tracks the spans of a tracer that are currently in flight along with samples
+// of recently finished spans, and an http.Handler that renders the contents of the registry as a debug page in the
+// style of OpenCensus zPages. This makes it possible to see which operations of a running service are stuck or slow
+// without attaching a debugger.
+//
+// Tracking is opt-in: a Registry is a wtracing.SpanProcessor that must be registered on a tracer using
+// wtracing.WithSpanProcessor.
+package zpages
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// LatencyBucketBounds are the lower bounds of the latency buckets that finished spans are sorted into. Bucket i
+// contains spans with a duration in [LatencyBucketBounds[i], LatencyBucketBounds[i+1]).
+var LatencyBucketBounds = []time.Duration{
+	0,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+	100 * time.Second,
+}
+
+const (
+	defaultSamplesPerBucket = 10
+	defaultMaxSpanNames     = 1000
+)
+
+// OverflowSpanName is the name under which the spans of a Registry are summarized once the registry tracks its maximum
+// number of distinct span names (see WithMaxSpanNames).
+const OverflowSpanName = "<other>"
+
+// RegistryOption configures a Registry.
+type RegistryOption func(r *Registry)
+
+// WithSamplesPerBucket sets the maximum number of recently finished spans that are retained for every latency bucket
+// (and for spans with errors) of every span name. Defaults to 10.
+func WithSamplesPerBucket(n int) RegistryOption {
+	return func(r *Registry) {
+		r.samplesPerBucket = n
+	}
+}
+
+// WithMaxSpanNames sets the maximum number of distinct span names for which summaries and samples are retained,
+// including OverflowSpanName: once n-1 span names are tracked, spans with new names are summarized under
+// OverflowSpanName so that the memory used by the registry is bounded even if span names are unbounded (for example, if
+// they include request paths). Defaults to 1000.
+func WithMaxSpanNames(n int) RegistryOption {
+	return func(r *Registry) {
+		r.maxSpanNames = n
+	}
+}
+
+// WithClock sets the clock used to determine the age of active spans. This should be the same clock that is used by
+// the tracer on which the registry is registered (see wtracing.WithClock). Defaults to the system clock.
+func WithClock(clock wtracing.Clock) RegistryOption {
+	return func(r *Registry) {
+		r.clock = clock
+	}
+}
+
+// ActiveSpan is a span that has been started but not finished.
+type ActiveSpan struct {
+	wtracing.SpanContext
+
+	Name  string
+	Kind  wtracing.Kind
+	Start time.Time
+	// Tags are the tags of the span when it was started. Tags that are set on the span after it was started are not
+	// included.
+	Tags map[string]string
+}
+
+// Summary summarizes the spans with a given name that have been tracked by a Registry.
+type Summary struct {
+	Name string
+	// Active is the number of spans with the name that are currently active.
+	Active int
+	// LatencyCounts contains the number of finished spans with the name in each latency bucket. The bucket at index i
+	// corresponds to LatencyBucketBounds[i].
+	LatencyCounts []int
+	// ErrorCount is the number of finished spans with the name that had an error tag.
+	ErrorCount int
+}
+
+// Registry is a wtracing.SpanProcessor that tracks active spans and retains samples of recently finished spans. It is
+// safe for concurrent use.
+type Registry struct {
+	samplesPerBucket int
+	maxSpanNames     int
+	clock            wtracing.Clock
+
+	mutex  sync.Mutex
+	active map[spanKey]ActiveSpan
+	names  map[string]*nameStats
+}
+
+// spanKey identifies an active span. The kind is part of the key because the client and server spans of an RPC share
+// the same TraceID and SpanID if the tracer uses shared spans.
+type spanKey struct {
+	traceID wtracing.TraceID
+	id      wtracing.SpanID
+	kind    wtracing.Kind
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type nameStats struct {
+	active        int
+	latencyCounts []int
+	errorCount    int
+	// latencySamples contains the most recent samples for every latency bucket.
+	latencySamples []*sampleRing
+	errorSamples   *sampleRing
+}
+
+// NewRegistry returns a new empty Registry.
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := &Registry{
+		samplesPerBucket: defaultSamplesPerBucket,
+		maxSpanNames:     defaultMaxSpanNames,
+		clock:            systemClock{},
+		active:           make(map[spanKey]ActiveSpan),
+		names:            make(map[string]*nameStats),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// OnStart records the span as active.
+func (r *Registry) OnStart(sc wtracing.SpanContext, name string, options *wtracing.SpanOptionImpl) {
+	tags := make(map[string]string, len(options.Tags))
+	for k, v := range options.Tags {
+		tags[k] = v
+	}
+
+	start := options.StartTime
+	if start.IsZero() {
+		start = r.clock.Now()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.active[spanKey{traceID: sc.TraceID, id: sc.ID, kind: options.Kind}] = ActiveSpan{
+		SpanContext: sc,
+		Name:        name,
+		Kind:        options.Kind,
+		Start:       start,
+		Tags:        tags,
+	}
+	r.stats(name).active++
+}
+
+// OnEnd removes the span from the active spans and records it as a sample for its latency bucket.
+func (r *Registry) OnEnd(span wtracing.SpanModel) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	stats := r.stats(span.Name)
+	key := spanKey{traceID: span.TraceID, id: span.ID, kind: span.Kind}
+	if activeSpan, ok := r.active[key]; ok {
+		delete(r.active, key)
+		// use the stats for the name that was used to start the span in case the two differ
+		r.stats(activeSpan.Name).active--
+	}
+
+	bucket := latencyBucket(span.Duration)
+	stats.latencyCounts[bucket]++
+	stats.latencySamples[bucket].add(span)
+	if _, ok := span.Tags[wtracing.ErrorTagKey]; ok {
+		stats.errorCount++
+		stats.errorSamples.add(span)
+	}
+}
+
+// ActiveSpans returns the spans that are currently active, ordered by start time (oldest first).
+func (r *Registry) ActiveSpans() []ActiveSpan {
+	r.mutex.Lock()
+	activeSpans := make([]ActiveSpan, 0, len(r.active))
+	for _, activeSpan := range r.active {
+		activeSpans = append(activeSpans, activeSpan)
+	}
+	r.mutex.Unlock()
+
+	sort.Slice(activeSpans, func(i, j int) bool {
+		return activeSpans[i].Start.Before(activeSpans[j].Start)
+	})
+	return activeSpans
+}
+
+// Summaries returns a summary for every span name tracked by the registry, ordered by name.
+func (r *Registry) Summaries() []Summary {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	summaries := make([]Summary, 0, len(r.names))
+	for name, stats := range r.names {
+		summaries = append(summaries, Summary{
+			Name:          name,
+			Active:        stats.active,
+			LatencyCounts: append([]int(nil), stats.latencyCounts...),
+			ErrorCount:    stats.errorCount,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+	return summaries
+}
+
+// LatencySamples returns the retained samples of finished spans with the provided name in the latency bucket with the
+// provided index, most recent first. Returns nil if there are no samples or if the index is out of range.
+func (r *Registry) LatencySamples(name string, bucket int) []wtracing.SpanModel {
+	if bucket < 0 || bucket >= len(LatencyBucketBounds) {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	stats, ok := r.names[name]
+	if !ok {
+		return nil
+	}
+	return stats.latencySamples[bucket].spans()
+}
+
+// ErrorSamples returns the retained samples of finished spans with the provided name that had an error tag, most
+// recent first.
+func (r *Registry) ErrorSamples(name string) []wtracing.SpanModel {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	stats, ok := r.names[name]
+	if !ok {
+		return nil
+	}
+	return stats.errorSamples.spans()
+}
+
+// SummaryName returns the name of the summary that spans with the provided name are recorded in: the name itself if
+// it is tracked by the registry and OverflowSpanName otherwise.
+func (r *Registry) SummaryName(name string) string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.names[name]; ok {
+		return name
+	}
+	return OverflowSpanName
+}
+
+// stats returns the stats for the provided name, creating them if necessary. If the registry already tracks its
+// maximum number of names (one of which is reserved for OverflowSpanName), returns the stats for OverflowSpanName
+// instead. Must be called while holding r.mutex.
+func (r *Registry) stats(name string) *nameStats {
+	stats, ok := r.names[name]
+	if !ok && r.maxSpanNames > 0 && len(r.names) >= r.maxSpanNames-1 {
+		name = OverflowSpanName
+		stats, ok = r.names[name]
+	}
+	if !ok {
+		stats = &nameStats{
+			latencyCounts:  make([]int, len(LatencyBucketBounds)),
+			latencySamples: make([]*sampleRing, len(LatencyBucketBounds)),
+			errorSamples:   newSampleRing(r.samplesPerBucket),
+		}
+		for i := range stats.latencySamples {
+			stats.latencySamples[i] = newSampleRing(r.samplesPerBucket)
+		}
+		r.names[name] = stats
+	}
+	return stats
+}
+
+func latencyBucket(d time.Duration) int {
+	if d < 0 {
+		return 0
+	}
+	// index of the first bound that is greater than d, minus 1
+	return sort.Search(len(LatencyBucketBounds), func(i int) bool {
+		return LatencyBucketBounds[i] > d
+	}) - 1
+}
+
+// sampleRing retains the most recent spans added to it, up to a fixed capacity.
+type sampleRing struct {
+	samples []wtracing.SpanModel
+	next    int
+	full    bool
+}
+
+func newSampleRing(capacity int) *sampleRing {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &sampleRing{
+		samples: make([]wtracing.SpanModel, capacity),
+	}
+}
+
+func (s *sampleRing) add(span wtracing.SpanModel) {
+	if len(s.samples) == 0 {
+		return
+	}
+	s.samples[s.next] = span
+	s.next = (s.next + 1) % len(s.samples)
+	if s.next == 0 {
+		s.full = true
+	}
+}
+
+// spans returns the spans in the ring, most recent first.
+func (s *sampleRing) spans() []wtracing.SpanModel {
+	n := s.next
+	if s.full {
+		n = len(s.samples)
+	}
+	spans := make([]wtracing.SpanModel, 0, n)
+	for i := 1; i <= n; i++ {
+		spans = append(spans, s.samples[(s.next-i+len(s.samples))%len(s.samples)])
+	}
+	return spans
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/zpages"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryWithTracer(t *testing.T) {
+	registry := zpages.NewRegistry()
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter(), wtracing.WithSpanProcessor(registry))
+	require.NoError(t, err)
+
+	rootSpan := tracer.StartSpan("root", wtracing.WithSpanTag("key", "value"))
+	childSpan := tracer.StartSpan("child", wtracing.WithParent(rootSpan))
+
+	activeSpans := registry.ActiveSpans()
+	require.Len(t, activeSpans, 2)
+	assert.Equal(t, "root", activeSpans[0].Name)
+	assert.Equal(t, rootSpan.Context(), activeSpans[0].SpanContext)
+	assert.Equal(t, map[string]string{"key": "value"}, activeSpans[0].Tags)
+	assert.Equal(t, "child", activeSpans[1].Name)
+
+	childSpan.Finish()
+	activeSpans = registry.ActiveSpans()
+	require.Len(t, activeSpans, 1)
+	assert.Equal(t, "root", activeSpans[0].Name)
+
+	rootSpan.Finish()
+	assert.Empty(t, registry.ActiveSpans())
+
+	summaries := registry.Summaries()
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "child", summaries[0].Name)
+	assert.Equal(t, 0, summaries[0].Active)
+	assert.Equal(t, 1, sum(summaries[0].LatencyCounts))
+	assert.Equal(t, "root", summaries[1].Name)
+}
+
+func TestRegistryUsesTracerClock(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := wtracingtest.NewManualClock(start)
+	registry := zpages.NewRegistry(zpages.WithClock(clock))
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter(), wtracing.WithClock(clock), wtracing.WithSpanProcessor(registry))
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("span")
+	defer span.Finish()
+
+	activeSpans := registry.ActiveSpans()
+	require.Len(t, activeSpans, 1)
+	assert.Equal(t, start, activeSpans[0].Start)
+}
+
+func TestRegistrySharedSpans(t *testing.T) {
+	registry := zpages.NewRegistry()
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter(), wtracing.WithSharedSpans(true), wtracing.WithSpanProcessor(registry))
+	require.NoError(t, err)
+
+	clientSpan := tracer.StartSpan("client", wtracing.WithKind(wtracing.Client))
+	serverSpan := tracer.StartSpan("server", wtracing.WithKind(wtracing.Server), wtracing.WithParentSpanContext(wtracing.SpanContext{
+		TraceID: clientSpan.Context().TraceID,
+		ID:      clientSpan.Context().ID,
+		Remote:  true,
+	}))
+	require.Equal(t, clientSpan.Context().ID, serverSpan.Context().ID)
+
+	// the client and server halves of a shared span are tracked separately
+	activeSpans := registry.ActiveSpans()
+	require.Len(t, activeSpans, 2)
+
+	serverSpan.Finish()
+	activeSpans = registry.ActiveSpans()
+	require.Len(t, activeSpans, 1)
+	assert.Equal(t, "client", activeSpans[0].Name)
+	assert.Equal(t, wtracing.Client, activeSpans[0].Kind)
+
+	clientSpan.Finish()
+	assert.Empty(t, registry.ActiveSpans())
+}
+
+func TestRegistryMaxSpanNames(t *testing.T) {
+	registry := zpages.NewRegistry(zpages.WithMaxSpanNames(3))
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter(), wtracing.WithSpanProcessor(registry))
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c", "d", "a"} {
+		tracer.StartSpan(name).Finish()
+	}
+	activeSpan := tracer.StartSpan("e")
+
+	// one of the names is reserved for the overflow summary
+	summaries := registry.Summaries()
+	require.Len(t, summaries, 3)
+	assert.Equal(t, zpages.OverflowSpanName, summaries[0].Name)
+	assert.Equal(t, 1, summaries[0].Active)
+	assert.Equal(t, 2, sum(summaries[0].LatencyCounts))
+	assert.Equal(t, "a", summaries[1].Name)
+	assert.Equal(t, 2, sum(summaries[1].LatencyCounts))
+	assert.Equal(t, "b", summaries[2].Name)
+	assert.Equal(t, "a", registry.SummaryName("a"))
+	assert.Equal(t, zpages.OverflowSpanName, registry.SummaryName("e"))
+
+	activeSpan.Finish()
+	assert.Equal(t, 0, registry.Summaries()[0].Active)
+}
+
+func TestRegistryLatencyBuckets(t *testing.T) {
+	registry := zpages.NewRegistry(zpages.WithSamplesPerBucket(2))
+
+	for i, d := range []time.Duration{
+		5 * time.Microsecond,
+		2 * time.Millisecond,
+		3 * time.Millisecond,
+		4 * time.Millisecond,
+		time.Hour,
+	} {
+		registry.OnEnd(wtracing.SpanModel{
+			SpanContext: wtracing.SpanContext{TraceID: "a", ID: wtracing.SpanID(rune('a' + i))},
+			Name:        "span",
+			Duration:    d,
+		})
+	}
+	registry.OnEnd(wtracing.SpanModel{
+		Name:     "span",
+		Duration: 50 * time.Millisecond,
+		Tags:     map[string]string{wtracing.ErrorTagKey: "failed"},
+	})
+
+	summaries := registry.Summaries()
+	require.Len(t, summaries, 1)
+	assert.Equal(t, []int{1, 0, 0, 3, 1, 0, 0, 0, 1}, summaries[0].LatencyCounts)
+	assert.Equal(t, 1, summaries[0].ErrorCount)
+
+	// only the 2 most recent samples are retained, most recent first
+	samples := registry.LatencySamples("span", 3)
+	require.Len(t, samples, 2)
+	assert.Equal(t, 4*time.Millisecond, samples[0].Duration)
+	assert.Equal(t, 3*time.Millisecond, samples[1].Duration)
+
+	assert.Len(t, registry.LatencySamples("span", 0), 1)
+	assert.Len(t, registry.LatencySamples("span", 8), 1)
+	assert.Nil(t, registry.LatencySamples("span", 9))
+	assert.Nil(t, registry.LatencySamples("unknown", 0))
+
+	errorSamples := registry.ErrorSamples("span")
+	require.Len(t, errorSamples, 1)
+	assert.Equal(t, "failed", errorSamples[0].Tags[wtracing.ErrorTagKey])
+}
+
+func TestHandler(t *testing.T) {
+	registry := zpages.NewRegistry()
+	sc := wtracing.SpanContext{TraceID: "463ac35c9f6413ad", ID: "72485a3953bb6124"}
+	registry.OnStart(sc, "stuck<operation>", &wtracing.SpanOptionImpl{Tags: map[string]string{"key": "value"}})
+	registry.OnEnd(wtracing.SpanModel{
+		SpanContext: wtracing.SpanContext{TraceID: "463ac35c9f6413ad", ID: "0000000000000001"},
+		Name:        "finished",
+		Duration:    2 * time.Millisecond,
+	})
+
+	server := httptest.NewServer(zpages.NewHandler(registry))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name         string
+		query        string
+		wantStatus   int
+		wantContains []string
+	}{
+		{
+			name:         "summary",
+			wantStatus:   http.StatusOK,
+			wantContains: []string{"stuck&lt;operation&gt;", "finished", "[1ms, 10ms)"},
+		},
+		{
+			name:         "active spans",
+			query:        "?type=active&name=stuck%3Coperation%3E",
+			wantStatus:   http.StatusOK,
+			wantContains: []string{"463ac35c9f6413ad", "72485a3953bb6124", "key=value"},
+		},
+		{
+			name:         "latency samples",
+			query:        "?type=latency&name=finished&bucket=3",
+			wantStatus:   http.StatusOK,
+			wantContains: []string{"0000000000000001", "2ms"},
+		},
+		{
+			name:       "invalid bucket",
+			query:      "?type=latency&name=finished&bucket=20",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid type",
+			query:      "?type=unknown",
+			wantStatus: http.StatusBadRequest,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tc.query)
+			require.NoError(t, err)
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+
+			body := new(strings.Builder)
+			_, err = io.Copy(body, resp.Body)
+			require.NoError(t, err)
+			for _, want := range tc.wantContains {
+				assert.Contains(t, body.String(), want)
+			}
+		})
+	}
+}
+
+func sum(vals []int) int {
+	total := 0
+	for _, v := range vals {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wzipkin
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/openzipkin/zipkin-go/idgenerator"
+	"github.com/openzipkin/zipkin-go/model"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// zipkinIDGenerator adapts a wtracing.IDGenerator to a Zipkin idgenerator.IDGenerator. Zipkin generators cannot return
+// errors, so malformed IDs returned by the wtracing.IDGenerator cause a panic.
+type zipkinIDGenerator struct {
+	generator wtracing.IDGenerator
+}
+
+func toZipkinIDGenerator(generator wtracing.IDGenerator) idgenerator.IDGenerator {
+	return zipkinIDGenerator{
+		generator: generator,
+	}
+}
+
+func (gen zipkinIDGenerator) TraceID() model.TraceID {
+	traceID := gen.generator.TraceID()
+	if len(traceID) != 16 && len(traceID) != 32 {
+		// TraceIDFromHex pads shorter IDs rather than rejecting them
+		panic(fmt.Errorf("IDGenerator returned TraceID %q that is not 16 or 32 hex characters", traceID))
+	}
+	zipkinTraceID, err := model.TraceIDFromHex(string(traceID))
+	if err != nil {
+		panic(fmt.Errorf("IDGenerator returned malformed TraceID %q: %v", traceID, err))
+	}
+	if zipkinTraceID.Empty() {
+		panic(fmt.Errorf("IDGenerator returned zero TraceID %q", traceID))
+	}
+	return zipkinTraceID
+}
+
+func (gen zipkinIDGenerator) SpanID(traceID model.TraceID) model.ID {
+	var wtracingTraceID wtracing.TraceID
+	if !traceID.Empty() {
+		wtracingTraceID = wtracing.TraceID(traceID.String())
+	}
+	spanID := gen.generator.SpanID(wtracingTraceID)
+	if len(spanID) != 16 {
+		panic(fmt.Errorf("IDGenerator returned SpanID %q that is not 16 hex characters", spanID))
+	}
+	id, err := strconv.ParseUint(string(spanID), 16, 64)
+	if err != nil {
+		panic(fmt.Errorf("IDGenerator returned malformed SpanID %q: %v", spanID, err))
+	}
+	return model.ID(id)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wzipkin_test
+
+import (
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedIDGenerator struct {
+	traceID wtracing.TraceID
+	spanID  wtracing.SpanID
+}
+
+func (g fixedIDGenerator) TraceID() wtracing.TraceID {
+	return g.traceID
+}
+
+func (g fixedIDGenerator) SpanID(traceID wtracing.TraceID) wtracing.SpanID {
+	return g.spanID
+}
+
+func TestTracerIDGenerator(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		generator fixedIDGenerator
+		wantPanic string
+	}{
+		{
+			name:      "64-bit TraceID",
+			generator: fixedIDGenerator{traceID: "6c2f558d62a7085f", spanID: "1a2b3c4d5e6f7081"},
+		},
+		{
+			name:      "128-bit TraceID",
+			generator: fixedIDGenerator{traceID: "5759e988bd862e3fe1be46a994272793", spanID: "1a2b3c4d5e6f7081"},
+		},
+		{
+			name:      "malformed TraceID",
+			generator: fixedIDGenerator{traceID: "not-a-hex-string", spanID: "1a2b3c4d5e6f7081"},
+			wantPanic: `IDGenerator returned malformed TraceID "not-a-hex-string": strconv.ParseUint: parsing "not-a-hex-string": invalid syntax`,
+		},
+		{
+			name:      "short TraceID",
+			generator: fixedIDGenerator{traceID: "6c2f", spanID: "1a2b3c4d5e6f7081"},
+			wantPanic: `IDGenerator returned TraceID "6c2f" that is not 16 or 32 hex characters`,
+		},
+		{
+			name:      "TraceID between 64 and 128 bits",
+			generator: fixedIDGenerator{traceID: "5759e988bd862e3fe1be", spanID: "1a2b3c4d5e6f7081"},
+			wantPanic: `IDGenerator returned TraceID "5759e988bd862e3fe1be" that is not 16 or 32 hex characters`,
+		},
+		{
+			name:      "zero TraceID",
+			generator: fixedIDGenerator{traceID: "0000000000000000", spanID: "1a2b3c4d5e6f7081"},
+			wantPanic: `IDGenerator returned zero TraceID "0000000000000000"`,
+		},
+		{
+			name:      "short SpanID",
+			generator: fixedIDGenerator{traceID: "6c2f558d62a7085f", spanID: "1a2b"},
+			wantPanic: `IDGenerator returned SpanID "1a2b" that is not 16 hex characters`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter(), wtracing.WithIDGenerator(tc.generator))
+			require.NoError(t, err)
+
+			if tc.wantPanic != "" {
+				assert.PanicsWithError(t, tc.wantPanic, func() {
+					tracer.StartSpan("mySpan")
+				})
+				return
+			}
+			span := tracer.StartSpan("mySpan")
+			defer span.Finish()
+			assert.Equal(t, tc.generator.traceID, span.Context().TraceID)
+			assert.Equal(t, tc.generator.spanID, span.Context().ID)
+		})
+	}
+}
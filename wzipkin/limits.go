@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wzipkin
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/openzipkin/zipkin-go"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// tagLimiter enforces the tag limits of a wtracing.SpanLimits for a single span. It tracks the keys of the tags that
+// have been set on the span so that updates to existing tags are allowed after the span has reached its maximum number
+// of tags and so that tags whose truncated keys collide with the key of another tag can be detected.
+type tagLimiter struct {
+	limits wtracing.SpanLimits
+
+	mu sync.Mutex
+	// keys maps the key of every tag set on the span to the original (untruncated) key that was used to set it.
+	keys    map[string]string
+	dropped int
+	// truncated contains the original keys of the tags whose key or value was truncated.
+	truncated map[string]struct{}
+}
+
+// newTagLimiter returns a new tagLimiter for the provided limits, or nil if the limits do not restrict tags.
+func newTagLimiter(limits wtracing.SpanLimits) *tagLimiter {
+	if limits.MaxTags <= 0 && limits.MaxTagKeyLength <= 0 && limits.MaxTagValueLength <= 0 {
+		return nil
+	}
+	return &tagLimiter{
+		limits:    limits,
+		keys:      make(map[string]string),
+		truncated: make(map[string]struct{}),
+	}
+}
+
+// initialTags returns the tags in the provided map that are within the limits, truncating keys and values as needed.
+// Tags are considered in sorted key order so that the tags that are dropped are deterministic.
+func (l *tagLimiter) initialTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return tags
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limitedTags := make(map[string]string, len(tags))
+	for _, k := range keys {
+		if key, value, ok := l.limit(k, tags[k]); ok {
+			limitedTags[key] = value
+		}
+	}
+	if l.dropped > 0 {
+		limitedTags[wtracing.DroppedTagsTagKey] = strconv.Itoa(l.dropped)
+	}
+	if len(l.truncated) > 0 {
+		limitedTags[wtracing.TruncatedTagsTagKey] = strconv.Itoa(len(l.truncated))
+	}
+	return limitedTags
+}
+
+// tag sets the provided tag on the provided span if it is within the limits, truncating the key and value as needed.
+// If the tag is dropped, updates the count of dropped tags on the span instead. If the tag is truncated, updates the
+// count of truncated tags on the span.
+func (l *tagLimiter) tag(span zipkin.Span, key, value string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	numTruncated := len(l.truncated)
+	limitedKey, limitedValue, ok := l.limit(key, value)
+	if !ok {
+		span.Tag(wtracing.DroppedTagsTagKey, strconv.Itoa(l.dropped))
+		return
+	}
+	span.Tag(limitedKey, limitedValue)
+	if len(l.truncated) != numTruncated {
+		span.Tag(wtracing.TruncatedTagsTagKey, strconv.Itoa(len(l.truncated)))
+	}
+}
+
+// limit returns the truncated key and value for the provided tag and whether the tag should be set. A tag is dropped
+// if it would add a new key to a span that has reached its maximum number of tags or if its truncated key is the same
+// as the key of a different tag that was already set (which would otherwise silently overwrite that tag). Must be
+// called while holding l.mu.
+func (l *tagLimiter) limit(key, value string) (string, string, bool) {
+	limitedKey := truncate(key, l.limits.MaxTagKeyLength)
+	limitedValue := truncate(value, l.limits.MaxTagValueLength)
+	if originalKey, ok := l.keys[limitedKey]; ok {
+		if originalKey != key {
+			l.dropped++
+			return "", "", false
+		}
+	} else {
+		if l.limits.MaxTags > 0 && len(l.keys) >= l.limits.MaxTags {
+			l.dropped++
+			return "", "", false
+		}
+		l.keys[limitedKey] = key
+	}
+	if limitedKey != key || limitedValue != value {
+		l.truncated[key] = struct{}{}
+	}
+	return limitedKey, limitedValue, true
+}
+
+// truncate returns the longest prefix of the provided string that is at most maxLen bytes long and does not end in a
+// partial UTF-8 encoded rune. Returns the string unmodified if maxLen is less than or equal to 0.
+func truncate(s string, maxLen int) string {
+	if maxLen <= 0 || len(s) <= maxLen {
+		return s
+	}
+	// back up to the start of the rune that contains the first byte that is cut off. Only a rune that starts within
+	// utf8.UTFMax bytes can be split: if no rune start is found in this range, the string is not valid UTF-8 at this
+	// point and is cut at maxLen.
+	for i := maxLen; i >= 0 && i > maxLen-utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			return s[:i]
+		}
+	}
+	return s[:maxLen]
+}
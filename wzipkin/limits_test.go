@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wzipkin_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracerMaxTagsPerSpan(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter, wtracing.WithMaxTagsPerSpan(2))
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("mySpan", wtracing.WithSpanTag("a", "1"), wtracing.WithSpanTag("b", "2"), wtracing.WithSpanTag("c", "3"))
+	span.Tag("d", "4")
+	// existing tags can still be updated after the limit has been reached
+	span.Tag("a", "updated")
+	span.Finish()
+
+	spans := reporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, map[string]string{
+		"a":                        "updated",
+		"b":                        "2",
+		wtracing.DroppedTagsTagKey: "2",
+	}, spans[0].Tags)
+}
+
+func TestTracerMaxTagLengths(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter,
+		wtracing.WithMaxTagKeyLength(4),
+		wtracing.WithMaxTagValueLength(5),
+		wtracing.WithMaxSpanNameLength(6),
+	)
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("mySpanName", wtracing.WithSpanTag("statement", "SELECT * FROM table"))
+	// "é" is encoded using 2 bytes and is dropped rather than split
+	span.Tag("body", "abcdé")
+	span.Tag("ok", "ok")
+	span.Finish()
+
+	spans := reporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "mySpan", spans[0].Name)
+	assert.Equal(t, map[string]string{
+		"stat":                       "SELEC",
+		"body":                       "abcd",
+		"ok":                         "ok",
+		wtracing.TruncatedTagsTagKey: "2",
+	}, spans[0].Tags)
+}
+
+func TestTracerTruncatedTagKeyCollision(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter, wtracing.WithMaxTagKeyLength(4))
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("mySpan", wtracing.WithSpanTag("http.method", "GET"))
+	// truncated keys that collide with the key of another tag are dropped rather than overwriting the existing tag
+	span.Tag("http.status", "200")
+	span.Tag("http", "value")
+	// tags with the same original key still update the existing value
+	span.Tag("http.method", "POST")
+	span.Finish()
+
+	spans := reporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, map[string]string{
+		"http":                       "POST",
+		wtracing.DroppedTagsTagKey:   "2",
+		wtracing.TruncatedTagsTagKey: "1",
+	}, spans[0].Tags)
+}
+
+func TestTracerNoSpanLimits(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+
+	longVal := strings.Repeat("a", 1<<16)
+	span := tracer.StartSpan(longVal)
+	for i := 0; i < 100; i++ {
+		span.Tag(strings.Repeat("k", i+1), longVal)
+	}
+	span.Finish()
+
+	spans := reporter.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, longVal, spans[0].Name)
+	assert.Len(t, spans[0].Tags, 100)
+}
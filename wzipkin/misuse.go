@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wzipkin
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// misuseDetector detects misuse of a single span for the WithSpanMisuseDetection tracer option.
+type misuseDetector struct {
+	handler       wtracing.SpanMisuseHandler
+	name          string
+	creationStack string
+	// finished is used as an atomic bool (1 = true, 0 = false)
+	finished int32
+}
+
+// detectMisuse configures the provided span to report misuse to the provided handler. Must be called by the goroutine
+// that started the span so that the recorded stack trace is that of the caller.
+func detectMisuse(span *spanImpl, name string, handler wtracing.SpanMisuseHandler) {
+	span.misuse = &misuseDetector{
+		handler:       handler,
+		name:          name,
+		creationStack: string(debug.Stack()),
+	}
+	runtime.SetFinalizer(span, func(s *spanImpl) {
+		if atomic.LoadInt32(&s.misuse.finished) == 0 {
+			s.misuse.handler(s.misuse.newMisuse(wtracing.SpanNotFinished, s.Context()))
+		}
+	})
+}
+
+// finish records that the span with the provided context was finished, reporting misuse if it was already finished.
+func (d *misuseDetector) finish(sc wtracing.SpanContext) {
+	if atomic.CompareAndSwapInt32(&d.finished, 0, 1) {
+		return
+	}
+	misuse := d.newMisuse(wtracing.SpanFinishedMultipleTimes, sc)
+	misuse.FinishStack = string(debug.Stack())
+	d.handler(misuse)
+}
+
+func (d *misuseDetector) newMisuse(misuseType wtracing.SpanMisuseType, sc wtracing.SpanContext) wtracing.SpanMisuse {
+	return wtracing.SpanMisuse{
+		Type:          misuseType,
+		Name:          d.name,
+		SpanContext:   sc,
+		CreationStack: d.creationStack,
+	}
+}
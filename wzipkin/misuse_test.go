@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wzipkin_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wzipkin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpanMisuseDetectionFinishedMultipleTimes(t *testing.T) {
+	misuses := make(chan wtracing.SpanMisuse, 10)
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter(), wtracing.WithSpanMisuseDetection(func(misuse wtracing.SpanMisuse) {
+		misuses <- misuse
+	}))
+	require.NoError(t, err)
+
+	span := tracer.StartSpan("mySpan")
+	span.Finish()
+	assert.Empty(t, misuses)
+
+	span.Finish()
+	require.Len(t, misuses, 1)
+	misuse := <-misuses
+	assert.Equal(t, wtracing.SpanFinishedMultipleTimes, misuse.Type)
+	assert.Equal(t, "mySpan", misuse.Name)
+	assert.Equal(t, span.Context(), misuse.SpanContext)
+	assert.Contains(t, misuse.CreationStack, "TestSpanMisuseDetectionFinishedMultipleTimes")
+	assert.Contains(t, misuse.FinishStack, "TestSpanMisuseDetectionFinishedMultipleTimes")
+
+	// garbage collecting a finished span is not misuse
+	span = nil
+	runtime.GC()
+	runtime.GC()
+	assert.Empty(t, misuses)
+}
+
+func TestSpanMisuseDetectionNotFinished(t *testing.T) {
+	misuses := make(chan wtracing.SpanMisuse, 10)
+	tracer, err := wzipkin.NewTracer(wtracing.NewNoopReporter(), wtracing.WithSpanMisuseDetection(func(misuse wtracing.SpanMisuse) {
+		misuses <- misuse
+	}))
+	require.NoError(t, err)
+
+	traceID := startUnfinishedSpan(tracer)
+
+	// finalizers run after a garbage collection cycle on a separate goroutine
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case misuse := <-misuses:
+			assert.Equal(t, wtracing.SpanNotFinished, misuse.Type)
+			assert.Equal(t, "leakedSpan", misuse.Name)
+			assert.Equal(t, traceID, misuse.SpanContext.TraceID)
+			assert.Contains(t, misuse.CreationStack, "startUnfinishedSpan")
+			assert.Empty(t, misuse.FinishStack)
+			return
+		case <-deadline:
+			require.Fail(t, "unfinished span was not reported")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+//go:noinline
+func startUnfinishedSpan(tracer wtracing.Tracer) wtracing.TraceID {
+	return tracer.StartSpan("leakedSpan").Context().TraceID
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Palantir Technologies. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wzipkin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openzipkin/zipkin-go"
+	"github.com/openzipkin/zipkin-go/model"
+	"github.com/palantir/witchcraft-go-tracing/wtracing"
+)
+
+// startProcessors notifies the provided processors that the provided span was started and sets any tags that the
+// processors added or modified in the span options on the span.
+func startProcessors(processors []wtracing.SpanProcessor, span wtracing.Span, name string, opts *wtracing.SpanOptionImpl) {
+	processorOpts := *opts
+	processorOpts.Tags = make(map[string]string, len(opts.Tags))
+	for k, v := range opts.Tags {
+		processorOpts.Tags[k] = v
+	}
+
+	sc := span.Context()
+	for _, processor := range processors {
+		processor.OnStart(sc, name, &processorOpts)
+	}
+
+	for k, v := range processorOpts.Tags {
+		if currVal, ok := opts.Tags[k]; !ok || currVal != v {
+			span.Tag(k, v)
+		}
+	}
+}
+
+func endProcessors(processors []wtracing.SpanProcessor, span wtracing.SpanModel) {
+	for _, processor := range processors {
+		processor.OnEnd(span)
+	}
+}
+
+// isSampled returns true if the zipkin tracer reports spans with the provided context when they are finished.
+func isSampled(sc model.SpanContext) bool {
+	return sc.Debug || (sc.Sampled != nil && *sc.Sampled)
+}
+
+// unsampledSpan wraps a zipkin.Span that is not sampled. Such spans are never sent to the reporter, so unsampledSpan
+// records the information of the span itself so that span processors can be provided with the model of the span when
+// it is finished.
+type unsampledSpan struct {
+	zipkin.Span
+	processors []wtracing.SpanProcessor
+	clock      wtracing.Clock
+
+	mu       sync.Mutex
+	model    wtracing.SpanModel
+	finished bool
+}
+
+func newUnsampledSpan(span zipkin.Span, processors []wtracing.SpanProcessor, clock wtracing.Clock, name string, localEndpoint *wtracing.Endpoint, start time.Time, shared bool, opts *wtracing.SpanOptionImpl) *unsampledSpan {
+	tags := make(map[string]string, len(opts.Tags))
+	for k, v := range opts.Tags {
+		tags[k] = v
+	}
+	return &unsampledSpan{
+		Span:       span,
+		processors: processors,
+		clock:      clock,
+		model: wtracing.SpanModel{
+			SpanContext:    fromZipkinSpanContext(span.Context()),
+			Name:           name,
+			Kind:           opts.Kind,
+			Timestamp:      start,
+			LocalEndpoint:  localEndpoint,
+			RemoteEndpoint: opts.RemoteEndpoint,
+			Tags:           tags,
+			Shared:         shared,
+		},
+	}
+}
+
+func (s *unsampledSpan) Tag(key string, value string) {
+	s.Span.Tag(key, value)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		// the model has already been provided to the processors
+		return
+	}
+	if _, ok := s.model.Tags[string(zipkin.TagError)]; ok && key == string(zipkin.TagError) {
+		// match the zipkin behavior of persisting the first value of error tags
+		return
+	}
+	s.model.Tags[key] = value
+}
+
+func (s *unsampledSpan) Annotate(timestamp time.Time, value string) {
+	s.Span.Annotate(timestamp, value)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		return
+	}
+	s.model.Annotations = append(s.model.Annotations, wtracing.Annotation{
+		Timestamp: timestamp,
+		Value:     value,
+	})
+}
+
+func (s *unsampledSpan) Finish() {
+	s.FinishedWithDuration(s.clock.Now().Sub(s.model.Timestamp))
+}
+
+func (s *unsampledSpan) FinishedWithDuration(d time.Duration) {
+	s.Span.FinishedWithDuration(d)
+
+	s.mu.Lock()
+	if s.finished {
+		s.mu.Unlock()
+		return
+	}
+	s.finished = true
+	s.model.Duration = d
+	spanModel := s.model
+	s.mu.Unlock()
+
+	endProcessors(s.processors, spanModel)
+}
@@ -20,18 +20,23 @@ import (
 	"github.com/palantir/witchcraft-go-tracing/wtracing"
 )
 
-func newZipkinReporterAdapter(rep wtracing.Reporter) reporter.Reporter {
+func newZipkinReporterAdapter(rep wtracing.Reporter, processors []wtracing.SpanProcessor) reporter.Reporter {
 	return &zipkinReporterAdapter{
-		reporter: rep,
+		reporter:   rep,
+		processors: processors,
 	}
 }
 
 type zipkinReporterAdapter struct {
 	reporter wtracing.Reporter
+	// processors are notified of every span before it is sent to the reporter.
+	processors []wtracing.SpanProcessor
 }
 
 func (r *zipkinReporterAdapter) Send(spanModel model.SpanModel) {
-	r.reporter.Send(fromZipkinSpanModel(spanModel))
+	wtracingSpanModel := fromZipkinSpanModel(spanModel)
+	endProcessors(r.processors, wtracingSpanModel)
+	r.reporter.Send(wtracingSpanModel)
 }
 
 func (r *zipkinReporterAdapter) Close() error {
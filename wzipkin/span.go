@@ -17,20 +17,32 @@ package wzipkin
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/openzipkin/zipkin-go"
 	"github.com/openzipkin/zipkin-go/model"
 	"github.com/palantir/witchcraft-go-tracing/wtracing"
 )
 
-func fromZipkinSpan(span zipkin.Span) wtracing.Span {
+func fromZipkinSpan(span zipkin.Span, limiter *tagLimiter, clock wtracing.Clock, start time.Time) *spanImpl {
 	return &spanImpl{
-		span: span,
+		span:    span,
+		limiter: limiter,
+		clock:   clock,
+		start:   start,
 	}
 }
 
 type spanImpl struct {
 	span zipkin.Span
+	// clock is used to determine the duration of the span when it is finished.
+	clock wtracing.Clock
+	// start is the time at which the span was started according to clock.
+	start time.Time
+	// limiter enforces the tag limits configured for the tracer. Nil if tags are not limited.
+	limiter *tagLimiter
+	// misuse detects misuse of the span. Nil if misuse detection is not enabled for the tracer.
+	misuse *misuseDetector
 }
 
 func (s *spanImpl) Context() wtracing.SpanContext {
@@ -38,11 +50,26 @@ func (s *spanImpl) Context() wtracing.SpanContext {
 }
 
 func (s *spanImpl) Tag(key string, value string) {
+	if s.limiter != nil {
+		s.limiter.tag(s.span, key, value)
+		return
+	}
 	s.span.Tag(key, value)
 }
 
+func (s *spanImpl) Annotate(timestamp time.Time, value string) {
+	s.span.Annotate(timestamp, value)
+}
+
 func (s *spanImpl) Finish() {
-	s.span.Finish()
+	s.FinishAt(s.clock.Now())
+}
+
+func (s *spanImpl) FinishAt(finishTime time.Time) {
+	if s.misuse != nil {
+		s.misuse.finish(s.Context())
+	}
+	s.span.FinishedWithDuration(finishTime.Sub(s.start))
 }
 
 func fromZipkinSpanContext(spanCtx model.SpanContext) wtracing.SpanContext {
@@ -70,8 +97,24 @@ func fromZipkinSpanModel(spanModel model.SpanModel) wtracing.SpanModel {
 		Duration:       spanModel.Duration,
 		LocalEndpoint:  fromZipkinEndpoint(spanModel.LocalEndpoint),
 		RemoteEndpoint: fromZipkinEndpoint(spanModel.RemoteEndpoint),
+		Annotations:    fromZipkinAnnotations(spanModel.Annotations),
 		Tags:           spanModel.Tags,
+		Shared:         spanModel.Shared,
+	}
+}
+
+func fromZipkinAnnotations(annotations []model.Annotation) []wtracing.Annotation {
+	if len(annotations) == 0 {
+		return nil
+	}
+	wtracingAnnotations := make([]wtracing.Annotation, len(annotations))
+	for i, annotation := range annotations {
+		wtracingAnnotations[i] = wtracing.Annotation{
+			Timestamp: annotation.Timestamp,
+			Value:     annotation.Value,
+		}
 	}
+	return wtracingAnnotations
 }
 
 func toZipkinSpanOptions(impl *wtracing.SpanOptionImpl) []zipkin.SpanOption {
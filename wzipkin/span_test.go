@@ -27,6 +27,15 @@ var implProvider = wtracingtests.ImplProvider{
 	TracerCreator: func(reporter wtracing.Reporter, opts ...wtracing.TracerOption) (wtracing.Tracer, error) {
 		return wzipkin.NewTracer(reporter, opts...)
 	},
+	Features: []wtracingtests.Feature{
+		wtracingtests.FeatureDefaultTags,
+		wtracingtests.FeatureSpanLimits,
+		wtracingtests.FeatureSpanProcessors,
+		wtracingtests.FeatureSpanMisuseDetection,
+		wtracingtests.FeatureSharedSpans,
+		wtracingtests.FeatureIDGenerator,
+		wtracingtests.FeatureClock,
+	},
 }
 
 func TestWZipkinImpl(t *testing.T) {
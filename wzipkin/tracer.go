@@ -16,6 +16,7 @@ package wzipkin
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/openzipkin/zipkin-go"
 	"github.com/openzipkin/zipkin-go/model"
@@ -23,19 +24,33 @@ import (
 )
 
 func NewTracer(rep wtracing.Reporter, opts ...wtracing.TracerOption) (wtracing.Tracer, error) {
-	zipkinReporter := newZipkinReporterAdapter(rep)
-	zipkinTracerOpts := toZipkinTracerOptions(wtracing.FromTracerOptions(opts...))
+	tracerOpts := wtracing.FromTracerOptions(opts...)
+	zipkinReporter := newZipkinReporterAdapter(rep, tracerOpts.SpanProcessors)
+	zipkinTracerOpts := toZipkinTracerOptions(tracerOpts)
 
 	zipkinTracer, err := zipkin.NewTracer(zipkinReporter, zipkinTracerOpts...)
 	if err != nil {
 		return nil, err
 	}
 
+	var sharedSpanTracer *zipkin.Tracer
+	if tracerOpts.SharedSpans {
+		// known that error cannot be nil: if it were, the first construction should have returned a non-nil error
+		sharedSpanTracer, _ = zipkin.NewTracer(zipkinReporter, withZipkinTracerOption(zipkinTracerOpts, zipkin.WithSharedSpans(true))...)
+	}
+
 	return &tracerImpl{
-		tracer: zipkinTracer,
+		tracer:           zipkinTracer,
+		sharedSpanTracer: sharedSpanTracer,
+		spanLimits:       tracerOpts.SpanLimits,
+		defaultTags:      tracerOpts.DefaultTags,
+		processors:       tracerOpts.SpanProcessors,
+		localEndpoint:    tracerOpts.LocalEndpoint,
+		misuseHandler:    tracerOpts.SpanMisuseHandler,
+		clock:            clockOrSystem(tracerOpts.Clock),
 		rootSpanTracerCreator: func(traceID model.TraceID) *zipkin.Tracer {
 			// add option that sets ID generator to be a fixed one that returns provided TraceID and SpanID based on it
-			opts := append(zipkinTracerOpts, zipkin.WithIDGenerator(fixedTraceIDRootSpanGenerator(traceID)))
+			opts := withZipkinTracerOption(zipkinTracerOpts, zipkin.WithIDGenerator(fixedTraceIDRootSpanGenerator(traceID)))
 
 			// known that error cannot be nil: if it were, the first construction should have returned a non-nil error
 			zipkinTracer, _ := zipkin.NewTracer(zipkinReporter, opts...)
@@ -44,6 +59,14 @@ func NewTracer(rep wtracing.Reporter, opts ...wtracing.TracerOption) (wtracing.T
 	}, nil
 }
 
+// withZipkinTracerOption returns a new slice that contains the provided options followed by the provided option. The
+// provided slice is not modified, so it is safe to call concurrently for the same slice.
+func withZipkinTracerOption(opts []zipkin.TracerOption, opt zipkin.TracerOption) []zipkin.TracerOption {
+	newOpts := make([]zipkin.TracerOption, 0, len(opts)+1)
+	newOpts = append(newOpts, opts...)
+	return append(newOpts, opt)
+}
+
 func toZipkinTracerOptions(impl *wtracing.TracerOptionImpl) []zipkin.TracerOption {
 	var zipkinTracerOptions []zipkin.TracerOption
 	zipkinTracerOptions = append(zipkinTracerOptions, zipkin.WithSharedSpans(false))
@@ -51,6 +74,9 @@ func toZipkinTracerOptions(impl *wtracing.TracerOptionImpl) []zipkin.TracerOptio
 	if impl.Sampler != nil {
 		zipkinTracerOptions = append(zipkinTracerOptions, zipkin.WithSampler(zipkin.Sampler(impl.Sampler)))
 	}
+	if impl.IDGenerator != nil {
+		zipkinTracerOptions = append(zipkinTracerOptions, zipkin.WithIDGenerator(toZipkinIDGenerator(impl.IDGenerator)))
+	}
 	return zipkinTracerOptions
 }
 
@@ -65,13 +91,65 @@ type tracerImpl struct {
 	// TraceID as its SpanID. The returned tracer should be configured in the same manner as the stored tracer except
 	// for this aspect.
 	rootSpanTracerCreator func(traceID model.TraceID) *zipkin.Tracer
+
+	// sharedSpanTracer is configured in the same manner as tracer, but creates server spans that share the span ID of
+	// their parent. Used to create server spans with remote parents if shared spans are enabled; nil otherwise.
+	sharedSpanTracer *zipkin.Tracer
+
+	// spanLimits are the limits enforced on the spans created by the tracer.
+	spanLimits wtracing.SpanLimits
+
+	// defaultTags are the tags added to every span created by the tracer. Tags provided as span options take
+	// precedence over default tags.
+	defaultTags map[string]string
+
+	// processors are notified when spans created by the tracer are started and finished.
+	processors []wtracing.SpanProcessor
+
+	// localEndpoint is the local endpoint of the spans created by the tracer.
+	localEndpoint *wtracing.Endpoint
+
+	// misuseHandler is called when misuse of a span created by the tracer is detected. Nil if misuse detection is not
+	// enabled.
+	misuseHandler wtracing.SpanMisuseHandler
+
+	// clock determines the start and finish times of spans created by the tracer.
+	clock wtracing.Clock
+}
+
+// systemClock is the wtracing.Clock used if a clock is not configured for the tracer.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func clockOrSystem(configured wtracing.Clock) wtracing.Clock {
+	if configured == nil {
+		return systemClock{}
+	}
+	return configured
 }
 
 func (t *tracerImpl) StartSpan(name string, options ...wtracing.SpanOption) wtracing.Span {
 	wtracingSpanOptions := wtracing.FromSpanOptions(options...)
-	zipkinSpanOptions := toZipkinSpanOptions(wtracingSpanOptions)
+	name = truncate(name, t.spanLimits.MaxNameLength)
+	if len(t.defaultTags) > 0 {
+		wtracingSpanOptions.Tags = withDefaultTags(t.defaultTags, wtracingSpanOptions.Tags)
+	}
+	limiter := newTagLimiter(t.spanLimits)
+	if limiter != nil {
+		wtracingSpanOptions.Tags = limiter.initialTags(wtracingSpanOptions.Tags)
+	}
+	start := wtracingSpanOptions.StartTime
+	if start.IsZero() {
+		start = t.clock.Now()
+		wtracingSpanOptions.StartTime = start
+	}
+	zipkinSpanOptions := append(toZipkinSpanOptions(wtracingSpanOptions), zipkin.StartTime(start))
 
 	tracer := t.tracer
+	shared := false
 	if parentSpan := wtracingSpanOptions.ParentSpan; parentSpan != nil &&
 		parentSpan.TraceID != "" &&
 		parentSpan.ID == "" {
@@ -82,8 +160,39 @@ func (t *tracerImpl) StartSpan(name string, options ...wtracing.SpanOption) wtra
 			panic(fmt.Errorf("malformed TraceID %s: this should not be possible at this point. Error: %v", parentSpan.TraceID, err))
 		}
 		tracer = t.rootSpanTracerCreator(traceID)
+	} else if t.sharedSpanTracer != nil &&
+		wtracingSpanOptions.Kind == wtracing.Server &&
+		parentSpan != nil &&
+		parentSpan.TraceID != "" &&
+		parentSpan.Remote {
+		// server span with a valid remote parent: share the span ID of the parent
+		tracer = t.sharedSpanTracer
+		shared = true
+	}
+	zipkinSpan := tracer.StartSpan(name, zipkinSpanOptions...)
+	if len(t.processors) > 0 && !isSampled(zipkinSpan.Context()) {
+		zipkinSpan = newUnsampledSpan(zipkinSpan, t.processors, t.clock, name, t.localEndpoint, start, shared, wtracingSpanOptions)
+	}
+	span := fromZipkinSpan(zipkinSpan, limiter, t.clock, start)
+	if t.misuseHandler != nil {
+		detectMisuse(span, name, t.misuseHandler)
+	}
+	if len(t.processors) > 0 {
+		startProcessors(t.processors, span, name, wtracingSpanOptions)
+	}
+	return span
+}
+
+// withDefaultTags returns a new map that contains the provided default tags overlaid with the provided span tags.
+func withDefaultTags(defaultTags, spanTags map[string]string) map[string]string {
+	tags := make(map[string]string, len(defaultTags)+len(spanTags))
+	for k, v := range defaultTags {
+		tags[k] = v
+	}
+	for k, v := range spanTags {
+		tags[k] = v
 	}
-	return fromZipkinSpan(tracer.StartSpan(name, zipkinSpanOptions...))
+	return tags
 }
 
 func toZipkinEndpoint(endpoint *wtracing.Endpoint) *model.Endpoint {
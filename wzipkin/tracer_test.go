@@ -16,8 +16,10 @@ package wzipkin_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/palantir/witchcraft-go-tracing/wtracing"
+	"github.com/palantir/witchcraft-go-tracing/wtracing/wtracingtest"
 	"github.com/palantir/witchcraft-go-tracing/wzipkin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,6 +113,20 @@ func TestTracerStartSpanWithTags(t *testing.T) {
 	assert.Equal(t, reporterMap["tags"].(map[string]string), map[string]string{"key0": "value0"})
 }
 
+func TestTracerStartSpanWithAnnotations(t *testing.T) {
+	reporter := wtracingtest.NewRecordingReporter()
+	tracer, err := wzipkin.NewTracer(reporter)
+	require.NoError(t, err)
+
+	annotationTime := time.Now()
+	span := tracer.StartSpan("mySpan")
+	span.(wtracing.SpanAnnotator).Annotate(annotationTime, "cache miss")
+	span.Finish()
+
+	spanModel := wtracingtest.RequireSpan(t, reporter.Spans(), "mySpan")
+	assert.Equal(t, []wtracing.Annotation{{Timestamp: annotationTime, Value: "cache miss"}}, spanModel.Annotations)
+}
+
 type testReporter struct {
 	reporterMap map[string]interface{}
 }